@@ -0,0 +1,331 @@
+package cfw
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Text is a string or byte slice argument for the append helpers.
+type Text interface {
+	~string | ~[]byte
+}
+
+// AppendExcerpt appends the Excerpt of s to dst and returns the extended buffer.
+func AppendExcerpt[T Text](dst []byte, s T, replace, phrase string, n int) []byte {
+	from, to, prefix, suffix, ok := excerpt(s, replace, phrase, n)
+	if !ok {
+		return dst
+	}
+
+	dst = append(dst, prefix...)
+	dst = append(dst, s[from:to]...)
+
+	return append(dst, suffix...)
+}
+
+// AppendStripLinks appends s to dst with all the HTML links removed and returns the extended buffer.
+func AppendStripLinks[T Text](dst []byte, s T) []byte {
+	return appendTokens(dst, newElementStripper(newReader(s), true, "a"))
+}
+
+// AppendStripTags appends s to dst with all the HTML tags removed and returns the extended buffer.
+func AppendStripTags[T Text](dst []byte, s T) []byte {
+	return appendTokens(dst, newStripper(newReader(s), StripOptions{}))
+}
+
+// AppendTruncate appends the Truncate of s to dst and returns the extended buffer.
+func AppendTruncate[T Text](dst []byte, s T, replace string, n int) []byte {
+	i, replace := truncate(s, replace, n)
+	if i < 0 {
+		return append(dst, s...)
+	}
+
+	dst = append(dst, s[:i]...)
+
+	return append(dst, replace...)
+}
+
+// AppendWordTruncate appends the WordTruncate of s to dst and returns the extended buffer.
+func AppendWordTruncate[T Text](dst []byte, s T, replace string, n int) []byte {
+	if replace == "" {
+		replace = ellipsis
+	}
+
+	if words(s) >= runeCount(s) {
+		return append(dst, s...)
+	}
+
+	count := 0
+
+	for i := 0; i < len(s); {
+		// skip the whitespace between words
+		r, size := decodeRune(s[i:])
+		if unicode.IsSpace(r) {
+			i += size
+
+			continue
+		}
+
+		if count > 0 {
+			dst = append(dst, ' ')
+		}
+
+		start := i
+		for i < len(s) {
+			if r, size = decodeRune(s[i:]); unicode.IsSpace(r) {
+				break
+			}
+
+			i += size
+		}
+
+		dst = append(dst, s[start:i]...)
+
+		if count++; count >= n {
+			break
+		}
+	}
+
+	if count < n && count > 0 {
+		dst = append(dst, ' ')
+	}
+
+	return append(dst, replace...)
+}
+
+// excerpt returns the byte offsets of s to keep for the Excerpt of the phrase,
+// together with the replacements to use before and after it.
+// The radius is counted in bytes the same as earlier versions, but the excerpt never splits a character.
+// If the phrase is not found in s, ok is false.
+func excerpt[T Text](s T, replace, phrase string, n int) (from, to int, prefix, suffix string, ok bool) {
+	if replace == "" {
+		replace = ellipsis
+	}
+
+	pos := index(s, phrase)
+	if pos < 0 {
+		return 0, 0, "", "", false
+	}
+	// Set start info based on whether the excerpt text found, including its radius, comes before the start of the string.
+	from = 0
+	if (pos - n) > 1 {
+		from = pos - n
+		prefix = replace
+	}
+	// Set end info based on whether the excerpt text found, including its radius, comes after the end of the string.
+	end := len(s)
+	if n <= len(s)-pos-len(phrase) {
+		end = pos + n
+		suffix = replace
+	}
+
+	to = len(s)
+	if ln := end + len(phrase); ln < len(s) {
+		to = ln
+	}
+	// a negative radius can put the end before the start
+	if to < from {
+		to = from
+	}
+
+	return offset(s, from, Bytes), offset(s, to, Bytes), prefix, suffix, true
+}
+
+// excerptWith returns the byte offsets of s to keep for the ExcerptWith of the phrase,
+// together with the replacements to use before and after it.
+// If the phrase is not found in s, ok is false.
+func excerptWith[T Text](s T, phrase string, n int, opts ExcerptOptions) (from, to int, prefix, suffix string, ok bool) {
+	pos := index(s, phrase)
+	if pos < 0 {
+		return 0, 0, "", "", false
+	}
+
+	end := pos + len(phrase)
+	// Set start info based on whether the excerpt text found, including its radius, comes before the start of the string.
+	from = 0
+	if (count(s[:pos], opts.Unit) - n) > 1 {
+		from = offsetLast(s[:pos], n, opts.Unit)
+		prefix = opts.replace()
+	}
+	// Set end info based on whether the excerpt text found, including its radius, comes after the end of the string.
+	to = len(s)
+	if n <= count(s[end:], opts.Unit) {
+		to = end + offset(s[end:], n, opts.Unit)
+		suffix = opts.replace()
+	}
+
+	if opts.Boundary {
+		from = wordStart(s, from, pos)
+		to, _ = wordEnd(s, end, to)
+	}
+
+	return from, to, prefix, suffix, true
+}
+
+// truncate returns the byte offset of s to cut for the Truncate and the replacement to append.
+// The length of s is counted in characters, but the cut is made in bytes the same as earlier versions,
+// without splitting a character.
+// If s doesn't need truncating, the offset is -1.
+func truncate[T Text](s T, replace string, n int) (int, string) {
+	if replace == "" {
+		replace = ellipsis
+	}
+
+	if runeCount(s) <= n {
+		return -1, replace
+	}
+
+	i := n - runeCount(replace)
+	if i < 0 {
+		// the replacement alone is longer than the limit
+		return 0, replace[:offset(replace, n, Runes)]
+	}
+
+	return offset(s, i, Bytes), replace
+}
+
+// truncateWith returns the byte offset of s to cut for the TruncateWith and the replacement to append.
+// If s doesn't need truncating, the offset is -1.
+func truncateWith[T Text](s T, n int, opts TruncateOptions) (int, string) {
+	replace := opts.replace()
+
+	if count(s, opts.Unit) <= n {
+		return -1, replace
+	}
+
+	limit := n
+	if !opts.Exclusive {
+		limit -= count(replace, opts.Unit)
+	}
+
+	if limit < 0 {
+		// the replacement alone is longer than the limit
+		return 0, replace[:offset(replace, n, opts.Unit)]
+	}
+
+	i := offset(s, limit, opts.Unit)
+	if opts.Boundary {
+		if j, ok := wordEnd(s, 0, i); ok {
+			i = j
+		}
+	}
+
+	return i, replace
+}
+
+// appendTokens appends all the HTML from t to dst.
+func appendTokens(dst []byte, t tokens) []byte {
+	for {
+		p, err := t.next()
+		if err != nil {
+			return dst
+		}
+
+		dst = append(dst, p...)
+	}
+}
+
+// decodeRune unpacks the first UTF-8 encoding in s and returns the rune and its width in bytes.
+func decodeRune[T Text](s T) (rune, int) {
+	if len(s) > 0 && s[0] < utf8.RuneSelf {
+		return rune(s[0]), 1
+	}
+
+	var buf [utf8.UTFMax]byte
+
+	n := copy(buf[:], s)
+
+	return utf8.DecodeRune(buf[:n])
+}
+
+// decodeLastRune unpacks the last UTF-8 encoding in s and returns the rune and its width in bytes.
+func decodeLastRune[T Text](s T) (rune, int) {
+	if len(s) > 0 && s[len(s)-1] < utf8.RuneSelf {
+		return rune(s[len(s)-1]), 1
+	}
+
+	var buf [utf8.UTFMax]byte
+
+	i := len(s) - utf8.UTFMax
+	if i < 0 {
+		i = 0
+	}
+
+	n := copy(buf[:], s[i:])
+
+	return utf8.DecodeLastRune(buf[:n])
+}
+
+// index returns the byte offset of the first instance of sub in s, or -1 if sub is not present.
+func index[T Text](s T, sub string) int {
+	switch v := any(s).(type) {
+	case string:
+		return strings.Index(v, sub)
+	case []byte:
+		return bytes.Index(v, []byte(sub))
+	}
+	// named string and byte slice types
+	return strings.Index(string(s), sub)
+}
+
+// newReader returns a reader of s without copying it.
+func newReader[T Text](s T) io.Reader {
+	switch v := any(s).(type) {
+	case string:
+		return strings.NewReader(v)
+	case []byte:
+		return bytes.NewReader(v)
+	}
+	// named string and byte slice types
+	if v := reflect.ValueOf(s); v.Kind() == reflect.String {
+		return strings.NewReader(v.String())
+	}
+
+	return bytes.NewReader(reflect.ValueOf(s).Bytes())
+}
+
+// runeCount returns the number of runes in s.
+func runeCount[T Text](s T) int {
+	n := 0
+
+	for i := 0; i < len(s); n++ {
+		if s[i] < utf8.RuneSelf {
+			i++
+
+			continue
+		}
+
+		_, size := decodeRune(s[i:])
+		i += size
+	}
+
+	return n
+}
+
+// words returns the number of words in s that are separated by whitespace.
+func words[T Text](s T) int {
+	n, space := 0, true
+
+	for i := 0; i < len(s); {
+		r, size := decodeRune(s[i:])
+		i += size
+
+		if unicode.IsSpace(r) {
+			space = true
+
+			continue
+		}
+
+		if space {
+			n++
+		}
+
+		space = false
+	}
+
+	return n
+}
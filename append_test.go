@@ -0,0 +1,135 @@
+package cfw_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleAppendTruncate() {
+	buf := make([]byte, 0, 64)
+	for _, body := range [][]byte{
+		[]byte("Go is an open source programming language"),
+		[]byte("Go is fun"),
+	} {
+		buf = cfw.AppendTruncate(buf[:0], body, "", 10)
+		fmt.Printf("%s\n", buf)
+	}
+	// Output: Go is a...
+	// Go is fun
+}
+
+func ExampleAppendStripTags() {
+	b := cfw.AppendStripTags([]byte("Text: "), []byte(`<p>The <b>Go</b> gopher</p>`))
+	fmt.Printf("%s\n", b)
+	// Output: Text: The Go gopher
+}
+
+type (
+	named      string
+	namedBytes []byte
+)
+
+func TestAppend(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"",
+		"this is a test to see if this works or not.",
+		"CFWheels: testing the excerpt view helper to see if it works or not.",
+		"The quick brown 🦊 jumps over the lazy 🐕",
+		"  leading and trailing  whitespace\t\n",
+		"a b c",
+		"ab",
+	}
+	const prefix = "prefix:"
+
+	for _, s := range inputs {
+		s := s
+		t.Run(s, func(t *testing.T) {
+			t.Parallel()
+			for _, n := range []int{0, 1, 3, 4, 20, 21, 100} {
+				want := prefix + cfw.WordTruncate(s, "", n)
+				check(t, "AppendWordTruncate", want,
+					cfw.AppendWordTruncate([]byte(prefix), s, "", n),
+					cfw.AppendWordTruncate([]byte(prefix), []byte(s), "", n),
+					cfw.AppendWordTruncate([]byte(prefix), named(s), "", n),
+					cfw.AppendWordTruncate([]byte(prefix), namedBytes(s), "", n))
+
+				for _, phrase := range []string{"", "test", "excerpt view helper", "🦊", "missing"} {
+					want := prefix + cfw.Excerpt(s, "[more]", phrase, n)
+					check(t, "AppendExcerpt", want,
+						cfw.AppendExcerpt([]byte(prefix), s, "[more]", phrase, n),
+						cfw.AppendExcerpt([]byte(prefix), []byte(s), "[more]", phrase, n),
+						cfw.AppendExcerpt([]byte(prefix), named(s), "[more]", phrase, n),
+						cfw.AppendExcerpt([]byte(prefix), namedBytes(s), "[more]", phrase, n))
+				}
+
+				want = prefix + cfw.Truncate(s, "[more]", n)
+				check(t, "AppendTruncate", want,
+					cfw.AppendTruncate([]byte(prefix), s, "[more]", n),
+					cfw.AppendTruncate([]byte(prefix), []byte(s), "[more]", n),
+					cfw.AppendTruncate([]byte(prefix), named(s), "[more]", n),
+					cfw.AppendTruncate([]byte(prefix), namedBytes(s), "[more]", n))
+			}
+		})
+	}
+}
+
+func TestAppendStrip(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"",
+		`Go to the <strong><a href="https://github.com/bengarrett/cfw">GitHub</a></strong> repo!`,
+		`<h1>this</h1><p><a href="http://www.google.com" title="google">is</a></p><p>a test</p>`,
+		`The quick <b><A HREF="https://example.com">brown 🦊</A></b> jumps<script>x()</script>`,
+	}
+	const prefix = "prefix:"
+
+	for _, s := range inputs {
+		want := prefix + cfw.StripTags(s)
+		check(t, "AppendStripTags", want,
+			cfw.AppendStripTags([]byte(prefix), s),
+			cfw.AppendStripTags([]byte(prefix), []byte(s)),
+			cfw.AppendStripTags([]byte(prefix), named(s)),
+			cfw.AppendStripTags([]byte(prefix), namedBytes(s)))
+
+		want = prefix + cfw.StripLinks(s)
+		check(t, "AppendStripLinks", want,
+			cfw.AppendStripLinks([]byte(prefix), s),
+			cfw.AppendStripLinks([]byte(prefix), []byte(s)),
+			cfw.AppendStripLinks([]byte(prefix), named(s)),
+			cfw.AppendStripLinks([]byte(prefix), namedBytes(s)))
+	}
+}
+
+// TestAppendReuse isn't run in parallel, as the allocations are counted across all goroutines.
+//
+//nolint:paralleltest
+func TestAppendReuse(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	src := []byte("<p>reuse the <b>buffer</b></p>")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = cfw.AppendTruncate(buf[:0], src, "", 10)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendTruncate() allocations = %v, want 0", allocs)
+	}
+
+	if got, want := string(buf), "<p>reus..."; got != want {
+		t.Errorf("AppendTruncate() = %q, want %q", got, want)
+	}
+}
+
+func check(t *testing.T, name, want string, got ...[]byte) {
+	t.Helper()
+
+	for i, b := range got {
+		if string(b) != want {
+			t.Errorf("%s() #%d = %q, want %q", name, i, b, want)
+		}
+	}
+}
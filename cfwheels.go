@@ -5,7 +5,7 @@ package cfw
 
 import (
 	"fmt"
-	"math"
+	"html"
 	"regexp"
 	"strconv"
 	"strings"
@@ -17,112 +17,350 @@ import (
 )
 
 const (
+	decimal      = 10
 	ellipsis     = "..."
 	hexadecimal  = 16
 	obfuscateXOR = 461
 	obfuscateSum = 154
 )
 
+// The regular expressions are compiled once, as compiling them is far slower than their use.
+var (
+	rxAnchorEnd     = regexp.MustCompile(`(?i)^</a\s*>`)
+	rxAnchorStart   = regexp.MustCompile(`(?i)^<a(?:\s|>)`)
+	rxBlankLines    = regexp.MustCompile(`\n(?:[ \t]*\n)+`)
+	rxCapital       = regexp.MustCompile(`([A-Z])`)
+	rxCapitalWord   = regexp.MustCompile(`([A-Z][a-z])`)
+	rxCapitals      = regexp.MustCompile(`([A-Z])\s([A-Z])(?:\s|\b)`)
+	rxCharRef       = regexp.MustCompile(`^&(?:[a-zA-Z][a-zA-Z0-9]*|#[0-9]+|#[xX][0-9a-fA-F]+);`)
+	rxDoubleSpace   = regexp.MustCompile(`(\s\s)`)
+	rxEmail         = regexp.MustCompile(`(?i)()(\b[a-z0-9._%+-]+@(?:[a-z0-9-]+\.)+[a-z]{2,}\b)`)
+	rxLeadHyphen    = regexp.MustCompile(`^-`)
+	rxLowerCapital  = regexp.MustCompile(`([a-z])([A-Z])`)
+	rxNonAlnumSpace = regexp.MustCompile(`([^[:alnum:]])[[:space:]]`)
+	rxTags          = regexp.MustCompile(`(?s)<!--.*?-->|<[^>]*>`)
+	rxURL           = regexp.MustCompile(`(?i)(\b(?:https?://|www\.)[^\s<>"']+)`)
+	rxURLEmail      = regexp.MustCompile(`(?i)(\b(?:https?://|www\.)[^\s<>"']+)|` +
+		`(\b[a-z0-9._%+-]+@(?:[a-z0-9-]+\.)+[a-z]{2,}\b)`)
+)
+
+// Link is the type of text that AutoLink converts into HTML links.
+type Link int
+
+const (
+	LinkAll    Link = iota // LinkAll links both URLs and email addresses.
+	LinkURLs               // LinkURLs only links URLs.
+	LinkEmails             // LinkEmails only links email addresses.
+)
+
+// Attr is an HTML attribute name and value pair.
+type Attr struct {
+	Name  string
+	Value string
+}
+
+// AutoLink converts the URLs and email addresses in s into HTML links.
+// The mode determines which are linked and the optional attrs are added to each link,
+// such as Attr{"rel", "nofollow"} or Attr{"target", "_blank"}.
+// Trailing punctuation and unbalanced closing brackets are not treated as part of a URL,
+// and any text found inside an existing HTML link or tag is left as is.
+// This function is a port of a CFWheels framework function programmed in ColdFusion (CFML).
+// https://github.com/cfwheels/cfwheels/blob/cf8e6da4b9a216b642862e7205345dd5fca34b54/wheels/view/text.cfm
+func AutoLink(s string, mode Link, attrs ...Attr) string {
+	rx := rxURLEmail
+
+	switch mode {
+	case LinkURLs:
+		rx = rxURL
+	case LinkEmails:
+		// the empty first group keeps the email address as the second submatch
+		rx = rxEmail
+	case LinkAll:
+	}
+
+	var (
+		b     strings.Builder
+		depth = 0
+	)
+
+	last := 0
+
+	for _, loc := range rxTags.FindAllStringIndex(s, -1) {
+		text, tag := s[last:loc[0]], s[loc[0]:loc[1]]
+		if depth > 0 {
+			b.WriteString(text)
+		} else {
+			b.WriteString(autoLink(rx, text, attrs...))
+		}
+
+		b.WriteString(tag)
+
+		switch {
+		case rxAnchorStart.MatchString(tag):
+			depth++
+		case rxAnchorEnd.MatchString(tag) && depth > 0:
+			depth--
+		}
+
+		last = loc[1]
+	}
+
+	if depth > 0 {
+		b.WriteString(s[last:])
+	} else {
+		b.WriteString(autoLink(rx, s[last:], attrs...))
+	}
+
+	return b.String()
+}
+
+// autoLink replaces the rx matches in the text with HTML links.
+// The first rx submatch must be a URL and the second an email address.
+func autoLink(rx *regexp.Regexp, text string, attrs ...Attr) string {
+	const url, email = 2, 4
+
+	var b strings.Builder
+
+	last := 0
+
+	for _, m := range rx.FindAllStringSubmatchIndex(text, -1) {
+		var href, link string
+
+		switch {
+		case m[url] >= 0 && m[url+1] > m[url]:
+			link = trimURL(cutEntity(text[m[url]:m[url+1]]))
+			if bareURL(link) {
+				continue
+			}
+
+			href = link
+			if strings.HasPrefix(strings.ToLower(link), "www.") {
+				href = "http://" + link
+			}
+		case len(m) > email && m[email] >= 0:
+			link = text[m[email]:m[email+1]]
+			href = "mailto:" + link
+		default:
+			continue
+		}
+
+		b.WriteString(text[last:m[0]])
+		b.WriteString(anchor(href, link, attrs...))
+
+		last = m[0] + len(link)
+	}
+
+	b.WriteString(text[last:])
+
+	return b.String()
+}
+
+// trimURL removes any trailing punctuation and unbalanced closing brackets from the url.
+func trimURL(url string) string {
+	for url != "" {
+		last := url[len(url)-1]
+
+		switch {
+		case strings.IndexByte(".,;:!?'\"*", last) >= 0:
+		case last == ')' && strings.Count(url, "(") < strings.Count(url, ")"):
+		case last == ']' && strings.Count(url, "[") < strings.Count(url, "]"):
+		case last == '}' && strings.Count(url, "{") < strings.Count(url, "}"):
+		default:
+			return url
+		}
+
+		url = url[:len(url)-1]
+	}
+
+	return url
+}
+
+// cutEntity removes everything from the url starting with the first HTML entity
+// for a character that cannot be part of a URL, such as &gt; or &quot;.
+func cutEntity(url string) string {
+	lower := strings.ToLower(url)
+	for _, entity := range []string{"&lt;", "&gt;", "&quot;", "&apos;", "&#34;", "&#39;"} {
+		if i := strings.Index(lower, entity); i >= 0 {
+			url, lower = url[:i], lower[:i]
+		}
+	}
+
+	return url
+}
+
+// bareURL reports whether the url is only a scheme or www prefix.
+func bareURL(url string) bool {
+	for _, prefix := range []string{"http://", "https://", "www."} {
+		if strings.EqualFold(url, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// anchor returns an HTML link to href using the text and attrs.
+// The href and text are HTML escaped, so a " character in a URL cannot end the href attribute.
+func anchor(href, text string, attrs ...Attr) string {
+	const markup = len(`<a href=""></a>`)
+
+	var b strings.Builder
+
+	b.Grow(markup + len(href) + len(text))
+	b.WriteString(`<a href="`)
+	b.WriteString(escapeRef(href))
+	b.WriteByte('"')
+
+	for _, a := range attrs {
+		if a.Name == "" || strings.EqualFold(a.Name, "href") {
+			continue
+		}
+
+		b.WriteString(" " + html.EscapeString(a.Name) + `="` + html.EscapeString(a.Value) + `"`)
+	}
+
+	b.WriteByte('>')
+	b.WriteString(escapeRef(text))
+	b.WriteString("</a>")
+
+	return b.String()
+}
+
+// escapeRef escapes the special HTML characters in s, except for the & of an existing character reference,
+// such as &amp; or &#39;, as the text is already HTML.
+func escapeRef(s string) string {
+	if !strings.ContainsAny(s, `&<>"'`) {
+		return s
+	}
+
+	var b strings.Builder
+
+	b.Grow(len(s))
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '&':
+			if rxCharRef.MatchString(s[i:]) {
+				b.WriteByte(c)
+
+				continue
+			}
+
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '"':
+			b.WriteString("&#34;")
+		case '\'':
+			b.WriteString("&#39;")
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
 // Deobfuscate the obfuscated string, or return the original string.
 // This function is a port of a CFWheels framework function programmed in ColdFusion (CFML).
 // See: https://github.com/cfwheels/cfwheels/blob/cf8e6da4b9a216b642862e7205345dd5fca34b54/wheels/global/misc.cfm#L508
 func DeObfuscate(s string) string {
-	const checksum, decimal = 2, 10
+	const checksum = 2
 	if len(s) < checksum {
 		return s
 	}
-	if i, _ := strconv.Atoi(s); i > 0 {
-		return s
+	if numeric(s) {
+		if i, _ := strconv.Atoi(s); i > 0 {
+			return s
+		}
+	}
+	if value, ok := deobfuscate(s, checksum); ok {
+		return value
+	}
+	// the checksum of a value with a digit sum over 101 is three hexadecimal digits
+	if value, ok := deobfuscate(s, checksum+1); ok {
+		return value
+	}
+
+	return s
+}
+
+// deobfuscate the obfuscated string s, where the checksum is the number of leading hexadecimal digits to check.
+func deobfuscate(s string, checksum int) (string, bool) {
+	if len(s) <= checksum {
+		return "", false
 	}
-	// deobfuscate string
 	num, err := strconv.ParseInt(s[checksum:], hexadecimal, 0)
 	if err != nil {
-		return s
+		return "", false
 	}
 	num ^= obfuscateXOR
-	baseNum := strconv.Itoa(int(num))
-	l := len(baseNum) - 1
-	value := ""
-	for i := 0; i < l; i++ {
-		f := baseNum[l-i:][:1]
-		value += f
-	}
+	// reverse the digits, except for the leading digit
+	var buf [20]byte
+	digits := strconv.AppendInt(buf[:0], num, decimal)
+	value := make([]byte, 0, len(digits))
 	// create checks
-	l = len(value)
 	chksumTest := 0
-	for i := 0; i < l; i++ {
-		chr := value[i : i+1]
-		n, err1 := strconv.Atoi(chr)
-		if err1 != nil {
-			return s
+	for i := len(digits) - 1; i > 0; i-- {
+		c := digits[i]
+		if c < '0' || c > '9' {
+			return "", false
 		}
-		chksumTest += n
+		value = append(value, c)
+		chksumTest += int(c - '0')
 	}
 	// run checks
-	chksum, err := strconv.ParseInt(s[:2], hexadecimal, 0)
+	chksum, err := strconv.ParseInt(s[:checksum], hexadecimal, 0)
 	if err != nil {
-		return s
+		return "", false
 	}
-	chksumX := strconv.FormatInt(chksum, decimal)
-	chksumY := strconv.FormatInt(int64(chksumTest+obfuscateSum), decimal)
-	if err := chksumX != chksumY; err {
-		return s
+	if chksum != int64(chksumTest+obfuscateSum) {
+		return "", false
 	}
 
-	return value
+	return string(value), true
 }
 
 // Excerpt replaces n characters from s, which match the first instance of a given phrase.
 // This function is a port of a CFWheels framework function programmed in ColdFusion (CFML).
 // See: https://github.com/cfwheels/cfwheels/blob/cf8e6da4b9a216b642862e7205345dd5fca34b54/wheels/global/misc.cfm#L68
+// Use ExcerptWith to configure the units, word boundaries and HTML handling.
 func Excerpt(s, replace, phrase string, n int) string {
-	if replace == "" {
-		replace = ellipsis
-	}
-
-	pos := strings.Index(s, phrase)
-
-	if pos < 0 {
+	from, to, prefix, suffix, ok := excerpt(s, replace, phrase, n)
+	if !ok {
 		return ""
 	}
-	// Set start info based on whether the excerpt text found, including its radius, comes before the start of the string.
-	sp, ts := 0, ""
-	if (pos - n) > 1 {
-		sp = pos - n
-		ts = replace
-	}
-	// Set end info based on whether the excerpt text found, including its radius, comes after the end of the string.
-	ep, te := len(s), ""
-	if (pos + len(phrase) + n) <= len(s) {
-		ep = pos + n
-		te = replace
-	}
-
-	var mid string
-	if ln := ep + len(phrase); ln >= len(s) {
-		mid = s[sp:]
-	} else {
-		mid = s[sp:ln]
-	}
 
-	return ts + mid + te
+	return prefix + s[from:to] + suffix
 }
 
 // Humanize returns readable text by separating camelCase strings to multiple, capitalized words.
 // This function is a port of a CFWheels framework function programmed in ColdFusion (CFML).
 // https://github.com/cfwheels/cfwheels/blob/632ea90547da368cddd77cefe17f42a7eda871e0/wheels/global/util.cfm#L53
 func Humanize(s string, except ...string) string {
+	return humanize(s, V1, except)
+}
+
+// humanize is the Humanize of the CFWheels version.
+func humanize(s string, v Version, except []string) string {
 	// Add a space before every capitalized word.
-	s = regexp.MustCompile(`([A-Z])`).ReplaceAllString(s, " $1")
+	s = rxCapital.ReplaceAllString(s, " $1")
+	if v >= V2 {
+		// Remove the space after a non-alphanumeric character, such as a / character.
+		s = rxNonAlnumSpace.ReplaceAllString(s, "$1")
+		// The exceptions are space separated lists.
+		except = strings.Fields(strings.Join(except, " "))
+	}
 	// Fix abbreviations so they form a word again (example: aURLVariable).
-	s = regexp.MustCompile(`([A-Z])\s([A-Z])(?:\s|\b)`).ReplaceAllString(s, "$1$2")
+	s = rxCapitals.ReplaceAllString(s, "$1$2")
 	// Handle exceptions.
 	for _, e := range except {
-		// (?i) case-insensitive
-		s = regexp.MustCompile(`(?i)`+e+`(?:\b)`).ReplaceAllString(s, e)
+		s = replaceWordFold(s, e)
 	}
 	// Support multiple word input by stripping out all double spaces created.
-	s = regexp.MustCompile(`(\s\s)`).ReplaceAllString(s, " ")
+	s = rxDoubleSpace.ReplaceAllString(s, " ")
 	// Capitalize the first letter and trim final result.
 	s = strings.TrimPrefix(s, " ")
 	c := cases.Title(language.English, cases.NoLower)
@@ -130,11 +368,57 @@ func Humanize(s string, except ...string) string {
 	return c.String(s)
 }
 
+// replaceWordFold replaces each instance of the word in s with the word, ignoring case,
+// where an instance must end at a word boundary, the same as the CFWheels (?i)word\b pattern.
+// The word is literal text, so it does not need to be compiled as a regular expression.
+func replaceWordFold(s, word string) string {
+	if word == "" || !utf8.ValidString(word) {
+		return s
+	}
+
+	var b strings.Builder
+
+	last := 0
+
+	for i := 0; i <= len(s); {
+		pos, end := indexFold(s[i:], word)
+		if pos < 0 {
+			break
+		}
+
+		pos, end = i+pos, i+end
+		if wordChar(s[end-1]) == (end < len(s) && wordChar(s[end])) {
+			_, size := utf8.DecodeRuneInString(s[pos:])
+			i = pos + size
+
+			continue
+		}
+
+		b.WriteString(s[last:pos])
+		b.WriteString(word)
+
+		last, i = end, end
+	}
+
+	if last == 0 {
+		return s
+	}
+
+	b.WriteString(s[last:])
+
+	return b.String()
+}
+
+// wordChar reports whether c is an ASCII word character, the same as the \w class of a regular expression.
+func wordChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
 // Hyphenize converts camelCase strings to a lowercase hyphened string.
 func Hyphenize(s string) string {
-	s = regexp.MustCompile(`([A-Z][a-z])`).ReplaceAllString(s, strings.ToLower(`-$1`))
-	s = regexp.MustCompile(`([a-z])([A-Z])`).ReplaceAllString(s, strings.ToLower(`$1-$2`))
-	s = regexp.MustCompile(`^-`).ReplaceAllString(strings.ToLower(s), "")
+	s = rxCapitalWord.ReplaceAllString(s, `-$1`)
+	s = rxLowerCapital.ReplaceAllString(s, `$1-$2`)
+	s = rxLeadHyphen.ReplaceAllString(strings.ToLower(s), "")
 
 	return s
 }
@@ -151,45 +435,89 @@ func Obfuscate(s string) string {
 	if s[0] == '0' {
 		return s
 	}
+	// the leading digit and the reversed digits must fit within an int64
+	const maxDigits = 18
+	l := len(s)
+	if l > maxDigits {
+		return s
+	}
 	reverse, err := ReverseInt(i)
 	if err != nil {
 		return s
 	}
-	l := len(s)
-	a := int(math.Pow10(l) + float64(reverse))
+	a := 1
+	for n := 0; n < l; n++ {
+		a *= decimal
+	}
+	a += reverse
 	b := 0
 	for i := 1; i <= l; i++ {
 		// slice and sum the individual digits
-		digit, err := strconv.Atoi(string(s[l-i]))
-		if err != nil {
+		c := s[l-i]
+		if c < '0' || c > '9' {
 			return s
 		}
-		b += digit
+		b += int(c - '0')
 	}
 	// base64 conversion
 	a ^= obfuscateXOR
 	b += obfuscateSum
 
-	return fmt.Sprintf("%s%s",
-		strconv.FormatInt(int64(b), hexadecimal),
-		strconv.FormatInt(int64(a), hexadecimal),
-	)
+	var buf [32]byte
+	hex := strconv.AppendInt(buf[:0], int64(b), hexadecimal)
+	hex = strconv.AppendInt(hex, int64(a), hexadecimal)
+
+	return string(hex)
+}
+
+// SimpleFormat replaces line breaks in s with HTML break tags and blank lines with paragraph tags.
+// Both CRLF and CR line endings are treated as LF, and a run of blank lines is a single paragraph break.
+// If escape is true, any HTML in s is escaped before the tags get added.
+// If wrap is true, the result is wrapped in a paragraph tag.
+// If wrap is false, the paragraphs are still separated by "</p>\n\n<p>", the same as CFWheels,
+// so the caller must add the opening <p> and closing </p> tags around the result.
+// This function is a port of a CFWheels framework function programmed in ColdFusion (CFML).
+// https://github.com/cfwheels/cfwheels/blob/cf8e6da4b9a216b642862e7205345dd5fca34b54/wheels/view/text.cfm
+func SimpleFormat(s string, wrap, escape bool) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+
+	if escape {
+		s = html.EscapeString(s)
+	}
+
+	paras := rxBlankLines.Split(s, -1)
+	for i, p := range paras {
+		paras[i] = strings.ReplaceAll(p, "\n", "<br />\n")
+	}
+
+	s = strings.Join(paras, "</p>\n\n<p>")
+	if wrap {
+		return "<p>" + s + "</p>"
+	}
+
+	return s
 }
 
 // StripLinks removes all HTML links from a string leaving just the link text.
 // This function is a port of a CFWheels framework function programmed in ColdFusion (CFML).
 // https://github.com/cfwheels/cfwheels/blob/daa7c43fc993cab00f52cf8ac881e6cc93c02fe1/wheels/view/sanitize.cfm#L3
 func StripLinks(s string) string {
-	return regexp.MustCompile(`<a.*?>(.*?)</a>`).ReplaceAllString(s, "$1")
+	return StripElements(s, true, "a")
 }
 
 // StripTags removes all HTML tags from a string.
+// Comments and the contents of script and style elements are also removed,
+// while block elements such as paragraphs and line breaks are replaced by whitespace.
+// HTML entities are returned as is, see StripTagsWith to decode them.
 // This function is a port of a CFWheels framework function programmed in ColdFusion (CFML).
 // https://github.com/cfwheels/cfwheels/blob/daa7c43fc993cab00f52cf8ac881e6cc93c02fe1/wheels/view/sanitize.cfm#L21
 func StripTags(s string) string {
-	x := regexp.MustCompile(`<\ *[a-z].*?>`).ReplaceAllString(s, "")
-
-	return regexp.MustCompile(`<\ */\ *[a-z].*?>`).ReplaceAllString(x, "")
+	return StripTagsWith(s, StripOptions{})
 }
 
 // TimeDistance describes the difference between two time values.
@@ -305,59 +633,62 @@ func lessMonths(mins, hrs int) string {
 // Truncate a string to the specified number and replace the trailing characters.
 // This function is a port of a CFWheels framework function programmed in ColdFusion (CFML).
 // https://github.com/cfwheels/cfwheels/blob/cf8e6da4b9a216b642862e7205345dd5fca34b54/wheels/global/misc.cfm#L20
+// Use TruncateWith to configure the units, word boundaries and HTML handling.
 func Truncate(s, replace string, n int) string {
-	if replace == "" {
-		replace = ellipsis
-	}
-
-	if utf8.RuneCountInString(s) <= n {
+	i, replace := truncate(s, replace, n)
+	if i < 0 {
 		return s
 	}
 
-	return s[0:n-utf8.RuneCountInString(replace)] + replace
+	return s[0:i] + replace
 }
 
 // WordTruncate truncates a string to the specified number of words and replaces the trailing characters.
 // This function is a port of a CFWheels framework function programmed in ColdFusion (CFML).
 // https://github.com/cfwheels/cfwheels/blob/cf8e6da4b9a216b642862e7205345dd5fca34b54/wheels/global/misc.cfm#L40
+// Use WordTruncateWith to configure the HTML handling.
 func WordTruncate(s, replace string, n int) string {
-	if replace == "" {
-		replace = ellipsis
-	}
-
-	words := strings.Fields(s)
-	if len(words) >= utf8.RuneCountInString(s) {
+	if words(s) >= utf8.RuneCountInString(s) {
 		return s
 	}
 
-	str := ""
-
-	for i, w := range words {
-		if i+1 >= n {
-			str += w
-
-			break
-		}
-
-		str += w + " "
-	}
-
-	return str + replace
+	return string(AppendWordTruncate(make([]byte, 0, len(s)+len(replace)+len(ellipsis)), s, replace, n))
 }
 
 // ReverseInt reverses an integer.
 func ReverseInt(i int) (int, error) {
 	// credit: Wade73
 	// http://stackoverflow.com/questions/35972561/reverse-int-golang
-	itoa, str := strconv.Itoa(i), ""
-	for x := len(itoa); x > 0; x-- {
-		str += string(itoa[x-1])
+	var buf [20]byte
+	itoa := strconv.AppendInt(buf[:0], int64(i), decimal)
+	for l, r := 0, len(itoa)-1; l < r; l, r = l+1, r-1 {
+		itoa[l], itoa[r] = itoa[r], itoa[l]
 	}
 
-	reverse, err := strconv.Atoi(str)
+	reverse, err := strconv.Atoi(string(itoa))
 	if err != nil {
 		return 0, fmt.Errorf("reverseInt %d: %w", i, err)
 	}
 
 	return reverse, nil
 }
+
+// numeric reports whether s is a decimal number with an optional sign,
+// which avoids the allocation of a strconv error for strings that are not numbers.
+func numeric(s string) bool {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+
+	if s == "" {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
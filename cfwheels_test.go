@@ -9,6 +9,91 @@ import (
 	"github.com/bengarrett/cfw"
 )
 
+func ExampleAutoLink() {
+	fmt.Println(cfw.AutoLink("Visit https://go.dev, or email gopher@example.com.", cfw.LinkAll))
+	fmt.Println(cfw.AutoLink("Visit www.example.com!", cfw.LinkURLs, cfw.Attr{Name: "rel", Value: "nofollow"}))
+	// Output: Visit <a href="https://go.dev">https://go.dev</a>, or email <a href="mailto:gopher@example.com">gopher@example.com</a>.
+	// Visit <a href="http://www.example.com" rel="nofollow">www.example.com</a>!
+}
+
+func TestAutoLink(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		s     string
+		mode  cfw.Link
+		attrs []cfw.Attr
+	}
+
+	const (
+		url    = `<a href="http://www.cfwheels.com">http://www.cfwheels.com</a>`
+		email  = `<a href="mailto:hello@cfwheels.com">hello@cfwheels.com</a>`
+		urls   = "Download CFWheels from http://www.cfwheels.com"
+		emails = "Email us at hello@cfwheels.com"
+		both   = "Download CFWheels from http://www.cfwheels.com or email hello@cfwheels.com"
+	)
+
+	nofollow := []cfw.Attr{{Name: "rel", Value: "nofollow"}, {Name: "target", Value: "_blank"}}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"empty", args{"", cfw.LinkAll, nil}, ""},
+		{"url", args{urls, cfw.LinkAll, nil}, "Download CFWheels from " + url},
+		{"email", args{emails, cfw.LinkAll, nil}, "Email us at " + email},
+		{"all", args{both, cfw.LinkAll, nil}, "Download CFWheels from " + url + " or email " + email},
+		{"urls only", args{both, cfw.LinkURLs, nil}, "Download CFWheels from " + url + " or email hello@cfwheels.com"},
+		{"emails only", args{both, cfw.LinkEmails, nil}, urls + " or email " + email},
+		{"www", args{"see www.example.com", cfw.LinkURLs, nil}, `see <a href="http://www.example.com">www.example.com</a>`},
+		{
+			"attrs", args{"https://go.dev", cfw.LinkURLs, nofollow},
+			`<a href="https://go.dev" rel="nofollow" target="_blank">https://go.dev</a>`,
+		},
+		{
+			"punctuation", args{"Is it https://go.dev/doc?", cfw.LinkURLs, nil},
+			`Is it <a href="https://go.dev/doc">https://go.dev/doc</a>?`,
+		},
+		{
+			"query", args{"https://go.dev/?q=1&p=2.", cfw.LinkURLs, nil},
+			`<a href="https://go.dev/?q=1&amp;p=2">https://go.dev/?q=1&amp;p=2</a>.`,
+		},
+		{
+			"parentheses", args{"(see https://go.dev/doc)", cfw.LinkURLs, nil},
+			`(see <a href="https://go.dev/doc">https://go.dev/doc</a>)`,
+		},
+		{
+			"balanced", args{"https://en.wikipedia.org/wiki/Go_(programming_language)", cfw.LinkURLs, nil},
+			`<a href="https://en.wikipedia.org/wiki/Go_(programming_language)">` +
+				`https://en.wikipedia.org/wiki/Go_(programming_language)</a>`,
+		},
+		{"bare scheme", args{"http://.", cfw.LinkURLs, nil}, "http://."},
+		{"linked", args{`<a href="https://go.dev">https://go.dev</a>`, cfw.LinkAll, nil}, `<a href="https://go.dev">https://go.dev</a>`},
+		{
+			"linked text", args{`<A HREF="/">mail hello@cfwheels.com</A> or hello@cfwheels.com`, cfw.LinkAll, nil},
+			`<A HREF="/">mail hello@cfwheels.com</A> or ` + email,
+		},
+		{"attribute", args{`<img src="http://example.com/a.png">`, cfw.LinkAll, nil}, `<img src="http://example.com/a.png">`},
+		{
+			"html", args{`<p>https://go.dev</p>`, cfw.LinkAll, nil},
+			`<p><a href="https://go.dev">https://go.dev</a></p>`,
+		},
+		{"emoji", args{"🦊 https://go.dev 🐕", cfw.LinkAll, nil}, `🦊 <a href="https://go.dev">https://go.dev</a> 🐕`},
+		{"entity", args{"&lt;https://go.dev&gt;", cfw.LinkURLs, nil}, `&lt;<a href="https://go.dev">https://go.dev</a>&gt;`},
+		{"ampersand", args{"https://go.dev/?a=1&amp;b=2", cfw.LinkURLs, nil}, `<a href="https://go.dev/?a=1&amp;b=2">https://go.dev/?a=1&amp;b=2</a>`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.AutoLink(tt.args.s, tt.args.mode, tt.args.attrs...); got != tt.want {
+				t.Errorf("AutoLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func ExampleDeObfuscate() {
 	fmt.Println(cfw.DeObfuscate("9b1c6"))
 	// Output: 1
@@ -35,6 +120,8 @@ func TestDeObfuscate(t *testing.T) {
 		{"error 1", "becca2515", "becca2515"},
 		{"error 2", "a15ba9", "a15ba9"},
 		{"error 3", "1111111111", "1111111111"},
+		{"checksum", "1061d1a94a1e32", "999999999999"},
+		{"long", "dd3e53751b238d7c", "1234567890123457"},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -85,6 +172,8 @@ func TestExcerpt(t *testing.T) {
 		{"5", args{s, "[more]", "see if it works", 25}, "[more]e excerpt view helper to see if it works or not."},
 		{"6", args{s, "[more]", "jklsduiermobk", 25}, ""},
 		{"utf8", args{"The quick brown 🦊 jumps over the lazy 🐕", "💬", "brown 🦊", 0}, "💬brown 🦊💬"},
+		{"mid-rune", args{"héllo wörld", "[more]", "wörld", 5}, "[more]éllo wörld"},
+		{"negative", args{"abc", "[more]", "a", -5}, "[more][more]"},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -127,6 +216,9 @@ func TestHumanize(t *testing.T) {
 		{"except", args{"ACfmlFramework", []string{"CFML"}}, "A CFML Framework"},
 		{"err 1", args{"wheelsIsACFMLFramework", nil}, "Wheels Is ACFML Framework"},
 		{"same", args{"Some Input", nil}, "Some Input"},
+		{"literal", args{"theURLIsAURL", []string{"U.L", "(URL"}}, "The URL Is AURL"},
+		{"boundary", args{"aCfmlxAndCfml", []string{"CFML"}}, "A Cfmlx And CFML"},
+		{"word character", args{"myUrl_idUrl", []string{"URL"}}, "My Url_id URL"},
 		{"emoji", args{"theQuickBrown🦊JumpsOverTheLazy🐕", nil}, "The Quick Brown🦊 Jumps Over The Lazy🐕"},
 	}
 	for _, tt := range tests {
@@ -203,6 +295,9 @@ func TestObfuscate(t *testing.T) {
 		{"", "69247541", "c06d44215"},
 		{"", "0413", "0413"},
 		{"", "per", "per"},
+		{"", "999999999999", "1061d1a94a1e32"},
+		{"", "1234567890123457", "dd3e53751b238d7c"},
+		{"", "1234567890123456789", "1234567890123456789"},
 		// in CFWheels this test fails but in Go it returns a429646180a
 		// {"", "1111111111", "1111111111"},
 	}
@@ -248,6 +343,57 @@ func TestReverseInt(t *testing.T) {
 	}
 }
 
+func ExampleSimpleFormat() {
+	fmt.Println(cfw.SimpleFormat("Hello\nworld!\n\nGoodbye.", true, false))
+	// Output: <p>Hello<br />
+	// world!</p>
+	//
+	// <p>Goodbye.</p>
+}
+
+func TestSimpleFormat(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		s      string
+		wrap   bool
+		escape bool
+	}
+
+	const (
+		line = "This is a test to see if this works or not."
+		str  = line + "\n" + line + "\n\n" + line
+		want = "<p>" + line + "<br />\n" + line + "</p>\n\n<p>" + line + "</p>"
+	)
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"empty", args{"", true, false}, ""},
+		{"ok", args{str, true, false}, want},
+		{"no wrap", args{"a\n\nb", false, false}, "a</p>\n\n<p>b"},
+		{"crlf", args{"a\r\nb\r\n\r\nc", true, false}, "<p>a<br />\nb</p>\n\n<p>c</p>"},
+		{"cr", args{"a\rb\r\rc", true, false}, "<p>a<br />\nb</p>\n\n<p>c</p>"},
+		{"blank run", args{"a\n\n \n\n\t\nb", true, false}, "<p>a</p>\n\n<p>b</p>"},
+		{"trim", args{"\n\n a \n\n", true, false}, "<p>a</p>"},
+		{"html", args{"<b>a</b>\nb", true, false}, "<p><b>a</b><br />\nb</p>"},
+		{"escape", args{"<b>a</b>\nb", true, true}, "<p>&lt;b&gt;a&lt;/b&gt;<br />\nb</p>"},
+		{"emoji", args{"brown 🦊\n\nlazy 🐕", true, true}, "<p>brown 🦊</p>\n\n<p>lazy 🐕</p>"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.SimpleFormat(tt.args.s, tt.args.wrap, tt.args.escape); got != tt.want {
+				t.Errorf("SimpleFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func ExampleStripLinks() {
 	fmt.Println(cfw.StripLinks(`<a href="https://golang.org">The Go Programming Language</a>.`))
 	// Output: The Go Programming Language.
@@ -271,6 +417,11 @@ func TestStripLinks(t *testing.T) {
 		{"empty", "", ""},
 		{"string", str, "this is a test to see if this works or not."},
 		{"emoji", emoji, "The quick <b>brown 🦊</b> jumps over the lazy 🐕"},
+		{"uppercase", `<A HREF="/">Home</A> <ABBR>page</ABBR>`, "Home <ABBR>page</ABBR>"},
+		{"multiline", "<a\nhref=\"/\"\n>multi\nline</a>", "multi\nline"},
+		{"attribute", `<a title="a > b" href="/">link</a>`, "link"},
+		{"comment", `<!-- <a href="/">kept</a> -->`, `<!-- <a href="/">kept</a> -->`},
+		{"script", `<script>var a = "<a href='/'>x</a>";</script>`, `<script>var a = "<a href='/'>x</a>";</script>`},
 	}
 
 	for _, tt := range tests {
@@ -305,8 +456,18 @@ func TestStripTags(t *testing.T) {
 		want string
 	}{
 		{"empty", "", ""},
-		{"string", str, "thisisa test tosee if this works or not."},
+		{"string", str, "this is a test to see if this works or not."},
 		{"emoji", emoji, "The quick brown 🦊 jumps over the lazy 🐕"},
+		{"uppercase", `<P>A <B>bold</B> move</P>`, "A bold move"},
+		{"multiline", "<a\nhref=\"/\">link</a>", "link"},
+		{"attribute", `<a title="1 > 0">yes</a>`, "yes"},
+		{"comment", `before<!-- <b>hidden</b> -->after`, "beforeafter"},
+		{"cdata", `a<![CDATA[<b>x</b>]]>b`, "ab"},
+		{"script", `<script>if (a < b) { alert("<b>") }</script>Text<style>p>b{}</style>`, "Text"},
+		{"entities", `&lt;b&gt; &amp; <i>x</i>`, "&lt;b&gt; &amp; x"},
+		{"less than", `1 < 2 and 3 > 2`, "1 < 2 and 3 > 2"},
+		{"blocks", `<ul><li>one</li><li>two</li></ul><p>three</p> <p>four</p>`, "one two three four"},
+		{"textarea", `<textarea><b>bold</b></textarea>`, "bold"},
 	}
 
 	for _, tt := range tests {
@@ -427,6 +588,9 @@ func TestTruncate(t *testing.T) {
 		{"err1", args{"", "[more]", 20}, ""},
 		{"ok2", args{"this is a test to see if this works or not.", "", 20}, "this is a test to..."},
 		{"emoji", args{"The quick brown 🦊 jumps over the lazy 🐕", "💬", 21}, "The quick brown 🦊💬"},
+		{"mid-rune", args{"héllo wörld", "💬", 3}, "h💬"},
+		{"short", args{"this is a test to see if this works or not.", "[more]", 3}, "[mo"},
+		{"negative", args{"this is a test", "", -1}, ""},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -523,3 +687,120 @@ func TestReadmeExamples(t *testing.T) {
 		t.Errorf("mismatch, got: %v, want: %v", a, e)
 	}
 }
+
+// The benchmarks report the allocations of the helpers used in hot paths, such as list pages,
+// so they can be tracked over time with benchstat.
+// There are no hard limits, as the counts depend on the compiler's inlining decisions and the race detector.
+// Run: go test -run ^$ -bench . -benchmem
+
+const benchHTML = `<h1>this</h1><p><a href="http://www.google.com" title="google">is</a></p><p>a ` +
+	`<a href="mailto:someone@example.com" title="invalid email">test</a> to<br>` +
+	`<a name="anchortag">see</a> if this works or not.</p>`
+
+func BenchmarkAutoLink(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cfw.AutoLink("Download CFWheels from http://www.cfwheels.com or email hello@cfwheels.com", cfw.LinkAll)
+	}
+}
+
+func BenchmarkDeObfuscate(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cfw.DeObfuscate("eb77359232")
+	}
+}
+
+func BenchmarkExcerpt(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cfw.Excerpt("CFWheels: testing the excerpt view helper to see if it works or not.",
+			"[more]", "excerpt view helper", 10)
+	}
+}
+
+func BenchmarkHumanize(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cfw.Humanize("wheelsIsAFramework")
+	}
+}
+
+func BenchmarkHyphenize(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cfw.Hyphenize("wheelsIsAFramework")
+	}
+}
+
+func BenchmarkObfuscate(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cfw.Obfuscate("999999999")
+	}
+}
+
+func BenchmarkReverseInt(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = cfw.ReverseInt(2345678)
+	}
+}
+
+func BenchmarkSimpleFormat(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cfw.SimpleFormat("Hello\nworld!\n\nGoodbye.", true, true)
+	}
+}
+
+func BenchmarkStripLinks(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cfw.StripLinks(benchHTML)
+	}
+}
+
+func BenchmarkStripTags(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cfw.StripTags(benchHTML)
+	}
+}
+
+func BenchmarkTimeDistance(b *testing.B) {
+	b.ReportAllocs()
+
+	n := time.Now()
+	to := n.Add(time.Minute * time.Duration(1440-1))
+
+	for i := 0; i < b.N; i++ {
+		cfw.TimeDistance(n, to, false)
+	}
+}
+
+func BenchmarkTruncate(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cfw.Truncate("this is a test to see if this works or not.", "[more]", 20)
+	}
+}
+
+func BenchmarkWordTruncate(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cfw.WordTruncate("CFWheels is a framework for ColdFusion", "", 4)
+	}
+}
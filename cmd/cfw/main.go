@@ -0,0 +1,298 @@
+// Command cfw runs the cfw string helpers from the command line.
+//
+// Usage:
+//
+//	cfw [-json] <command> [flags] [input...]
+//
+// Each input argument is processed in turn, otherwise when there are no input arguments,
+// each line read from the standard input is processed.
+//
+// The commands are:
+//
+//	obfuscate      obfuscate numeric IDs
+//	deobfuscate    deobfuscate obfuscated IDs
+//	truncate       truncate text to a number of characters
+//	excerpt        excerpt text around a phrase
+//	humanize       separate camelCase text into capitalized words
+//	hyphenize      convert camelCase text to a lowercase hyphened string
+//	strip-tags     remove all HTML tags from text
+//	time-distance  describe the time between a date and now
+//
+// Use "cfw <command> -h" for the flags of a command.
+// The -json flag prints each result as a JSON object on a line of its own.
+// The exit status is 1 when any input fails, such as an excerpt phrase that is not found.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bengarrett/cfw"
+)
+
+var (
+	// ErrCommand is returned when the command is unknown.
+	ErrCommand = errors.New("unknown command")
+	// ErrFlag is returned when a required flag is missing.
+	ErrFlag = errors.New("missing required flag")
+	// ErrPhrase is returned when the excerpt phrase is not found in the input.
+	ErrPhrase = errors.New("phrase not found")
+	// ErrTime is returned when a date cannot be parsed.
+	ErrTime = errors.New("unknown date format, use RFC 3339 or YYYY-MM-DD")
+)
+
+// result is the JSON output of a processed input.
+type result struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+	Error  string `json:"error,omitempty"`
+}
+
+// maxLine is the maximum length of a line read from the standard input,
+// which is larger than the default of bufio.Scanner to allow for long lines of HTML.
+const maxLine = 64 * 1024 * 1024
+
+// helper processes the input of a command.
+type helper func(s string) (string, error)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run the cfw command using the args and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	const ok, fail, usage = 0, 1, 2
+
+	fs := flag.NewFlagSet("cfw", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print the results as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: cfw [-json] <command> [flags] [input...]")
+		fmt.Fprintln(stderr, "commands: "+strings.Join(names(), ", "))
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return usage
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+
+		return usage
+	}
+
+	name := fs.Arg(0)
+
+	cmd, inputs, err := command(name, fs.Args()[1:], stderr)
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(stderr, "cfw %s: %s\n", name, err)
+		}
+
+		if errors.Is(err, ErrCommand) {
+			fs.Usage()
+		}
+
+		return usage
+	}
+
+	var lines func(func(string) bool) error
+
+	if len(inputs) > 0 {
+		lines = func(yield func(string) bool) error {
+			for _, s := range inputs {
+				if !yield(s) {
+					break
+				}
+			}
+
+			return nil
+		}
+	} else {
+		lines = func(yield func(string) bool) error {
+			scanner := bufio.NewScanner(stdin)
+			scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLine)
+
+			for scanner.Scan() {
+				if !yield(scanner.Text()) {
+					break
+				}
+			}
+
+			return scanner.Err()
+		}
+	}
+
+	code := ok
+	enc := json.NewEncoder(stdout)
+	enc.SetEscapeHTML(false)
+
+	// werr is the error of a failed write to stdout, which stops the processing of the inputs.
+	var werr error
+
+	err = lines(func(s string) bool {
+		out, err := cmd(s)
+		if err != nil {
+			code = fail
+		}
+
+		if *asJSON {
+			r := result{Input: s, Output: out}
+			if err != nil {
+				r.Error = err.Error()
+			}
+
+			werr = enc.Encode(r)
+
+			return werr == nil
+		}
+
+		if err != nil {
+			fmt.Fprintf(stderr, "cfw %s: %s\n", name, err)
+
+			return true
+		}
+
+		_, werr = fmt.Fprintln(stdout, out)
+
+		return werr == nil
+	})
+	if err == nil {
+		err = werr
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "cfw %s: %s\n", name, err)
+
+		return fail
+	}
+
+	return code
+}
+
+// command returns the helper for the named command and the remaining input arguments.
+func command(name string, args []string, stderr io.Writer) (helper, []string, error) {
+	fs := flag.NewFlagSet("cfw "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var cmd helper
+
+	switch name {
+	case "obfuscate":
+		cmd = func(s string) (string, error) { return cfw.Obfuscate(s), nil }
+	case "deobfuscate":
+		cmd = func(s string) (string, error) { return cfw.DeObfuscate(s), nil }
+	case "truncate":
+		const length = 30
+		n := fs.Int("n", length, "the maximum number of characters")
+		replace := fs.String("replace", "...", "the replacement for the truncated characters")
+		cmd = func(s string) (string, error) { return cfw.Truncate(s, *replace, *n), nil }
+	case "excerpt":
+		const radius = 100
+		phrase := fs.String("phrase", "", "the phrase to excerpt (required)")
+		n := fs.Int("radius", radius, "the number of characters to keep either side of the phrase")
+		replace := fs.String("replace", "...", "the replacement for the removed characters")
+		cmd = func(s string) (string, error) { return excerpt(s, *replace, *phrase, *n) }
+	case "humanize":
+		except := fs.String("except", "", "a comma separated list of words to keep as is, such as CFML,URL")
+		cmd = func(s string) (string, error) { return cfw.Humanize(s, list(*except)...), nil }
+	case "hyphenize":
+		cmd = func(s string) (string, error) { return cfw.Hyphenize(s), nil }
+	case "strip-tags":
+		decode := fs.Bool("decode", false, "decode HTML entities")
+		inline := fs.Bool("inline", false, "do not replace block elements with whitespace")
+		cmd = func(s string) (string, error) {
+			return cfw.StripTagsWith(s, cfw.StripOptions{Decode: *decode, Inline: *inline}), nil
+		}
+	case "time-distance":
+		to := fs.String("to", "", "the date to compare against instead of now")
+		seconds := fs.Bool("seconds", false, "describe distances of under a minute in seconds")
+		cmd = func(s string) (string, error) { return distance(s, *to, *seconds) }
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrCommand, name)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if name == "excerpt" && fs.Lookup("phrase").Value.String() == "" {
+		return nil, nil, fmt.Errorf("%w: -phrase", ErrFlag)
+	}
+
+	return cmd, fs.Args(), nil
+}
+
+// excerpt returns the excerpt of the phrase in s, or an error if the phrase is not found.
+func excerpt(s, replace, phrase string, n int) (string, error) {
+	out := cfw.Excerpt(s, replace, phrase, n)
+	if out == "" {
+		return "", fmt.Errorf("%w: %q", ErrPhrase, phrase)
+	}
+
+	return out, nil
+}
+
+// layouts are the accepted date formats of the time-distance command.
+var layouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// distance returns the time distance between the from and to dates, where an empty to is now.
+func distance(from, to string, seconds bool) (string, error) {
+	f, err := parseTime(from)
+	if err != nil {
+		return "", err
+	}
+
+	t := time.Now()
+	if to != "" {
+		if t, err = parseTime(to); err != nil {
+			return "", err
+		}
+	}
+
+	if t.Before(f) {
+		f, t = t, f
+	}
+
+	return cfw.TimeDistance(f, t, seconds), nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrTime, s)
+}
+
+// list splits the comma separated list and removes any empty items.
+func list(s string) []string {
+	items := []string{}
+
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// names returns the command names.
+func names() []string {
+	return []string{
+		"obfuscate", "deobfuscate", "truncate", "excerpt", "humanize",
+		"hyphenize", "strip-tags", "time-distance",
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  string
+		code  int
+	}{
+		{"no command", nil, "", "", 2},
+		{"unknown", []string{"reverse"}, "", "", 2},
+		{"obfuscate", []string{"obfuscate", "1", "99"}, "", "9b1c6\nac10a\n", 0},
+		{"obfuscate stdin", []string{"obfuscate"}, "15765\n69247541\n", "b226582\nc06d44215\n", 0},
+		{"deobfuscate", []string{"deobfuscate", "eb77359232"}, "", "999999999\n", 0},
+		{"truncate", []string{"truncate", "-n", "20", "-replace", "[more]", "this is a test to see if this works or not."}, "", "this is a test[more]\n", 0},
+		{"truncate default", []string{"truncate"}, "CFWheels is a framework for ColdFusion", "CFWheels is a framework for...\n", 0},
+		{"excerpt", []string{"excerpt", "-phrase", "excerpt view helper", "-radius", "10", "-replace", "[more]"}, "CFWheels: testing the excerpt view helper to see if it works or not.", "[more]sting the excerpt view helper to see if[more]\n", 0},
+		{"excerpt no phrase", []string{"excerpt", "text"}, "", "", 2},
+		{"excerpt not found", []string{"excerpt", "-phrase", "missing", "a", "a missing b"}, "", "a missing b\n", 1},
+		{"json excerpt not found", []string{"-json", "excerpt", "-phrase", "missing", "a"}, "", `{"input":"a","output":"","error":"phrase not found: \"missing\""}` + "\n", 1},
+		{"humanize", []string{"humanize", "-except", "CFML, URL", "aCfmlUrlFramework"}, "", "A CFML URL Framework\n", 0},
+		{"hyphenize", []string{"hyphenize", "wheelsIsAFramework"}, "", "wheels-is-a-framework\n", 0},
+		{"strip tags", []string{"strip-tags"}, "<p>a &amp; b</p><p>c</p>\n<b>d</b>", "a &amp; b c\nd\n", 0},
+		{"strip tags decode", []string{"strip-tags", "-decode", "-inline", "<p>a &amp; b</p><p>c</p>"}, "", "a & bc\n", 0},
+		{"time distance", []string{"time-distance", "-to", "2020-06-30", "2000-01-01"}, "", "over 20 years\n", 0},
+		{"time distance seconds", []string{"time-distance", "-seconds", "-to", "2006-01-02T15:04:10+07:00", "2006-01-02T15:04:06+07:00"}, "", "less than 5 seconds\n", 0},
+		{"time distance reversed", []string{"time-distance", "-to", "2000-01-01", "2020-06-30"}, "", "over 20 years\n", 0},
+		{"time distance error", []string{"time-distance", "yesterday"}, "", "", 1},
+		{"json", []string{"-json", "obfuscate", "1", "<a>"}, "", `{"input":"1","output":"9b1c6"}` + "\n" + `{"input":"<a>","output":"<a>"}` + "\n", 0},
+		{"json error", []string{"-json", "time-distance", "-to", "2000-01-01", "soon"}, "", `{"input":"soon","output":"","error":"unknown date format, use RFC 3339 or YYYY-MM-DD: \"soon\""}` + "\n", 1},
+		{"json empty", []string{"-json", "strip-tags", "<br>"}, "", `{"input":"<br>","output":""}` + "\n", 0},
+		{"help", []string{"truncate", "-h"}, "", "", 2},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("run() = %d, want %d: %s", code, tt.code, stderr.String())
+			}
+			if got := stdout.String(); got != tt.want {
+				t.Errorf("run() output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// errWriter is a writer that always fails.
+type errWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestRunWriteError(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{{"obfuscate", "1"}, {"-json", "obfuscate", "1"}} {
+		var stderr bytes.Buffer
+		if code := run(args, strings.NewReader(""), errWriter{}, &stderr); code != 1 {
+			t.Errorf("run(%q) = %d, want 1", args, code)
+		}
+		if !strings.Contains(stderr.String(), errWrite.Error()) {
+			t.Errorf("run(%q) stderr = %q, want the write error", args, stderr.String())
+		}
+	}
+}
+
+func TestRunLongLine(t *testing.T) {
+	t.Parallel()
+
+	const size = 1024 * 1024
+
+	var stdout, stderr bytes.Buffer
+	line := "<p>" + strings.Repeat("a", size) + "</p>"
+	if code := run([]string{"strip-tags"}, strings.NewReader(line), &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, want 0: %s", code, stderr.String())
+	}
+	if got := stdout.Len(); got != size+1 {
+		t.Errorf("run() output length = %d, want %d", got, size+1)
+	}
+}
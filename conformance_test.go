@@ -0,0 +1,184 @@
+package cfw_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bengarrett/cfw"
+)
+
+// The CFWheels conformance suite runs the golden files in testdata/conformance,
+// which contain the CFWheels output of each helper for the given arguments.
+// A derived case has a want that was worked out from the CFML source of the helper,
+// rather than copied from the output of the CFWheels test suite, so it is only as reliable as that reading.
+// A case with a deviation is a known difference between CFWheels and cfw,
+// where got is the cfw output that is expected instead.
+// A golden file with a version of 2 is run using the CFWheels 2.x helpers of cfw.Helpers.
+// Use go test -run TestConformance -v to print the compatibility report.
+
+// golden is a conformance file of a CFWheels helper.
+type golden struct {
+	Helper  string   `json:"helper"`
+	Version int      `json:"version"` // Version is the major CFWheels version, which defaults to 1.
+	Source  string   `json:"source"`
+	Cases   []vector `json:"cases"`
+}
+
+// vector is a conformance test case.
+type vector struct {
+	Name      string `json:"name"`
+	Args      params `json:"args"`
+	Want      string `json:"want"`
+	Derived   bool   `json:"derived"`   // Derived is true when want was worked out from the CFML source.
+	Throws    bool   `json:"throws"`    // Throws is true when CFWheels throws an error.
+	Deviation string `json:"deviation"` // Deviation describes why cfw differs from CFWheels.
+	Got       string `json:"got"`       // Got is the cfw output of a deviation.
+}
+
+// params are the arguments of a CFWheels helper, as decoded from JSON.
+type params []interface{}
+
+func (a params) str(i int) string {
+	s, _ := a[i].(string)
+
+	return s
+}
+
+func (a params) num(i int) int {
+	f, _ := a[i].(float64)
+
+	return int(f)
+}
+
+func (a params) boolean(i int) bool {
+	b, _ := a[i].(bool)
+
+	return b
+}
+
+// link returns the cfw mode of the CFWheels link argument, which is all, URLs or emailAddresses.
+func (a params) link(i int) cfw.Link {
+	switch a.str(i) {
+	case "URLs":
+		return cfw.LinkURLs
+	case "emailAddresses":
+		return cfw.LinkEmails
+	default:
+		return cfw.LinkAll
+	}
+}
+
+func (a params) strs(i int) []string {
+	list, _ := a[i].([]interface{})
+	s := make([]string, 0, len(list))
+
+	for _, v := range list {
+		s = append(s, fmt.Sprint(v))
+	}
+
+	return s
+}
+
+// conformers run the cfw equivalent of each CFWheels helper.
+var conformers = map[string]func(h cfw.Helpers, a params) string{
+	"autoLink":         func(_ cfw.Helpers, a params) string { return cfw.AutoLink(a.str(0), a.link(1)) },
+	"deobfuscateParam": func(_ cfw.Helpers, a params) string { return cfw.DeObfuscate(a.str(0)) },
+	"distanceOfTimeInWords": func(_ cfw.Helpers, a params) string {
+		from := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		return cfw.TimeDistance(from, from.Add(time.Duration(a.num(0))*time.Second), a.boolean(1))
+	},
+	"excerpt":        func(h cfw.Helpers, a params) string { return h.Excerpt(a.str(0), a.str(1), a.str(2), a.num(3)) },
+	"humanize":       func(h cfw.Helpers, a params) string { return h.Humanize(a.str(0), a.strs(1)...) },
+	"hyphenize":      func(_ cfw.Helpers, a params) string { return cfw.Hyphenize(a.str(0)) },
+	"obfuscateParam": func(_ cfw.Helpers, a params) string { return cfw.Obfuscate(a.str(0)) },
+	"simpleFormat":   func(_ cfw.Helpers, a params) string { return cfw.SimpleFormat(a.str(0), a.boolean(1), false) },
+	"stripLinks":     func(_ cfw.Helpers, a params) string { return cfw.StripLinks(a.str(0)) },
+	"stripTags":      func(_ cfw.Helpers, a params) string { return cfw.StripTags(a.str(0)) },
+	"truncate":       func(h cfw.Helpers, a params) string { return h.Truncate(a.str(0), a.str(1), a.num(2)) },
+	"wordTruncate":   func(h cfw.Helpers, a params) string { return h.WordTruncate(a.str(0), a.str(1), a.num(2)) },
+}
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	files, err := filepath.Glob(filepath.Join("testdata", "conformance", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var report strings.Builder
+
+	w := tabwriter.NewWriter(&report, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "helper\tcases\tcompatible\tderived\tdeviations\t")
+
+	for _, name := range files {
+		g := golden{}
+		if err := readGolden(name, &g); err != nil {
+			t.Fatal(err)
+		}
+
+		run, ok := conformers[g.Helper]
+		if !ok {
+			t.Errorf("%s: unknown helper %q", name, g.Helper)
+
+			continue
+		}
+
+		h, label := cfw.Helpers{Version: cfw.V1}, g.Helper
+		if g.Version >= 2 {
+			h.Version, label = cfw.V2, fmt.Sprintf("%s v%d", g.Helper, g.Version)
+		}
+
+		deviations, derived := 0, 0
+
+		t.Run(label, func(t *testing.T) {
+			for _, v := range g.Cases {
+				got := run(h, v.Args)
+
+				if v.Derived {
+					derived++
+				}
+
+				switch {
+				case v.Deviation != "":
+					deviations++
+
+					if got != v.Got {
+						t.Errorf("%s: %s() = %q, want the deviation %q, update %s if the deviation is fixed",
+							v.Name, g.Helper, got, v.Got, name)
+					}
+				case v.Throws:
+					t.Errorf("%s: CFWheels throws an error and is missing a deviation", v.Name)
+				case got != v.Want:
+					t.Errorf("%s: %s() = %q, want %q", v.Name, g.Helper, got, v.Want)
+				}
+			}
+		})
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t\n", label, len(g.Cases), len(g.Cases)-deviations, derived, deviations)
+	}
+
+	w.Flush()
+	t.Log("CFWheels compatibility\n" + report.String() +
+		"The derived cases were worked out from the CFML source and are not checked against CFWheels.")
+}
+
+func readGolden(name string, g *golden) error {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, g); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return nil
+}
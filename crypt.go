@@ -0,0 +1,316 @@
+package cfw
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// Encoding is the text encoding of the binary output of Encrypt.
+type Encoding int
+
+const (
+	EncodeUU     Encoding = iota // EncodeUU is the UUencode format, which is the CFML default.
+	EncodeBase64                 // EncodeBase64 is the standard, padded Base64 format.
+	EncodeHex                    // EncodeHex is the uppercase hexadecimal format.
+)
+
+var (
+	// ErrEncoding is returned when the encrypted text is not in the given encoding.
+	ErrEncoding = errors.New("invalid encoding")
+	// ErrGCMKey is returned when the AES key is not 16, 24 or 32 bytes.
+	ErrGCMKey = errors.New("aes key must be 16, 24 or 32 bytes")
+)
+
+const (
+	uuLine    = 45   // uuLine is the maximum number of bytes encoded on a line of UUencode.
+	uuOffset  = ' '  // uuOffset is added to each 6-bit value of UUencode.
+	uuMask    = 0x3f // uuMask is the 6-bit value of UUencode.
+	seedSize  = 12   // seedSize is the number of key characters used to seed the CFMX_COMPAT registers.
+	lfsrBytes = 4
+)
+
+// Encrypt encrypts s with the key using the CFML CFMX_COMPAT algorithm,
+// which is the default algorithm of the CFML Encrypt function, and encodes the result.
+// Any values encrypted by CFML using Encrypt(s, key) can be read using Decrypt(s, key, EncodeUU).
+//
+// CFMX_COMPAT is an XOR stream cipher that is not secure and should only be used to read legacy values,
+// which can be moved to AES-GCM using MigrateGCM.
+func Encrypt(s, key string, enc Encoding) string {
+	b := cfmxCompat([]byte(s), key)
+
+	switch enc {
+	case EncodeBase64:
+		return base64.StdEncoding.EncodeToString(b)
+	case EncodeHex:
+		return strings.ToUpper(hex.EncodeToString(b))
+	case EncodeUU:
+		return uuEncode(b)
+	default:
+		return uuEncode(b)
+	}
+}
+
+// Decrypt decodes and decrypts the text created by Encrypt or the CFML Encrypt function,
+// using the key and the CFMX_COMPAT algorithm.
+// An error is returned if s is not in the encoding, but as CFMX_COMPAT has no integrity check,
+// a wrong key returns the wrong text without an error.
+func Decrypt(s, key string, enc Encoding) (string, error) {
+	var (
+		b   []byte
+		err error
+	)
+
+	switch enc {
+	case EncodeBase64:
+		b, err = base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	case EncodeHex:
+		b, err = hex.DecodeString(strings.TrimSpace(s))
+	case EncodeUU:
+		b, err = uuDecode(s)
+	default:
+		b, err = uuDecode(s)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("decrypt: %w: %s", ErrEncoding, err)
+	}
+
+	return string(cfmxCompat(b, key)), nil
+}
+
+// MigrateGCM decrypts the text created by the CFML Encrypt function using the CFMX_COMPAT key,
+// and returns it encrypted with AES-GCM using the aesKey, which can be read using DecryptGCM.
+func MigrateGCM(s, key string, enc Encoding, aesKey []byte) (string, error) {
+	plain, err := Decrypt(s, key, enc)
+	if err != nil {
+		return "", err
+	}
+
+	return EncryptGCM(plain, aesKey)
+}
+
+// EncryptGCM encrypts s using AES-GCM with a random nonce, and returns the nonce followed by
+// the sealed text in the standard Base64 encoding. The aesKey must be 16, 24 or 32 bytes to use
+// AES-128, AES-192 or AES-256.
+func EncryptGCM(s string, aesKey []byte) (string, error) {
+	gcm, err := newGCM(aesKey)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(s)+gcm.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("encrypt gcm: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(s), nil)), nil
+}
+
+// DecryptGCM decrypts the text created by EncryptGCM or MigrateGCM using the aesKey.
+// Unlike Decrypt, an error is returned if the key is wrong or the text was changed.
+func DecryptGCM(s string, aesKey []byte) (string, error) {
+	gcm, err := newGCM(aesKey)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return "", fmt.Errorf("decrypt gcm: %w: %s", ErrEncoding, err)
+	}
+
+	if len(b) < gcm.NonceSize() {
+		return "", fmt.Errorf("decrypt gcm: %w: too short", ErrEncoding)
+	}
+
+	plain, err := gcm.Open(nil, b[:gcm.NonceSize()], b[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypt gcm: %w", err)
+	}
+
+	return string(plain), nil
+}
+
+func newGCM(aesKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d bytes", ErrGCMKey, len(aesKey))
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// lfsr is the state of the three linear feedback shift registers of the CFMX_COMPAT algorithm.
+type lfsr struct {
+	a, b, c uint32
+}
+
+const (
+	lfsrA = 0x13579bdf
+	lfsrB = 0x2468ace0
+	lfsrC = 0xfdb97531
+	maskA = 0x80000062
+	maskB = 0x40000020
+	maskC = 0x10000002
+	rot0A = 0x7fffffff
+	rot0B = 0x3fffffff
+	rot0C = 0x0fffffff
+	rot1A = 0x80000000
+	rot1B = 0xc0000000
+	rot1C = 0xf0000000
+)
+
+// cfmxCompat returns b transformed with the key, which both encrypts and decrypts.
+// The key is repeated to at least 12 UTF-16 code units, and all three registers are seeded
+// with the code units 4 to 7, the same as the Java implementations of CFML.
+func cfmxCompat(b []byte, key string) []byte {
+	seed := make([]uint16, seedSize)
+	units := utf16.Encode([]rune(key))
+	copy(seed, units)
+
+	for i := len(units); i < seedSize; i++ {
+		seed[i] = seed[i-len(units)]
+	}
+
+	r := lfsr{}
+	for i := 0; i < lfsrBytes; i++ {
+		r.a = r.a<<8 | uint32(seed[i+lfsrBytes])
+		r.b = r.b<<8 | uint32(seed[i+lfsrBytes])
+		r.c = r.c<<8 | uint32(seed[i+lfsrBytes])
+	}
+
+	if r.a == 0 {
+		r.a = lfsrA
+	}
+
+	if r.b == 0 {
+		r.b = lfsrB
+	}
+
+	if r.c == 0 {
+		r.c = lfsrC
+	}
+
+	out := make([]byte, len(b))
+	for i, c := range b {
+		out[i] = c ^ r.next()
+	}
+
+	return out
+}
+
+// next returns the next byte of the key stream.
+// The shifts follow the Java operator precedence, so A ^ Mask >>> 1 | Rot1 is (A ^ (Mask >>> 1)) | Rot1,
+// which has the same precedence in Go.
+func (r *lfsr) next() byte {
+	var crypto byte
+
+	b, c := r.b&1, r.c&1
+
+	for i := 0; i < 8; i++ {
+		if r.a&1 != 0 {
+			r.a = r.a ^ maskA>>1 | rot1A
+
+			if r.b&1 != 0 {
+				r.b, b = r.b^maskB>>1|rot1B, 1
+			} else {
+				r.b, b = r.b>>1&rot0B, 0
+			}
+		} else {
+			r.a = r.a >> 1 & rot0A
+
+			if r.c&1 != 0 {
+				r.c, c = r.c^maskC>>1|rot1C, 1
+			} else {
+				r.c, c = r.c>>1&rot0C, 0
+			}
+		}
+
+		crypto = crypto<<1 | byte(b^c)
+	}
+
+	return crypto
+}
+
+// uuEncode returns b in the UUencode format used by CFML, without the begin and end lines.
+// Each line encodes up to 45 bytes and ends with a newline,
+// and a final line with a length of zero is added when the length of b is a multiple of 45.
+func uuEncode(b []byte) string {
+	var sb strings.Builder
+
+	for {
+		n := len(b)
+		if n > uuLine {
+			n = uuLine
+		}
+
+		sb.WriteByte(uuOffset + byte(n))
+
+		for i := 0; i < n; i += 3 {
+			var chunk [3]byte
+
+			copy(chunk[:], b[i:n])
+			sb.WriteByte(uuOffset + chunk[0]>>2)
+			sb.WriteByte(uuOffset + (chunk[0]<<4|chunk[1]>>4)&uuMask)
+			sb.WriteByte(uuOffset + (chunk[1]<<2|chunk[2]>>6)&uuMask)
+			sb.WriteByte(uuOffset + chunk[2]&uuMask)
+		}
+
+		sb.WriteByte('\n')
+
+		b = b[n:]
+		if n < uuLine {
+			return sb.String()
+		}
+	}
+}
+
+// uuDecode returns the bytes of the UUencode lines in s, where either a space or a ` is a zero.
+func uuDecode(s string) ([]byte, error) {
+	out := []byte{}
+
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+
+		n := int(uuValue(line[0]))
+		if n > uuLine || (len(line)-1)/4 < (n+2)/3 {
+			return nil, fmt.Errorf("uuencode line %q", line)
+		}
+
+		for i, j := 1, 0; j < n; i, j = i+4, j+3 {
+			var v [4]byte
+
+			for k := range v {
+				c := line[i+k]
+				if c < uuOffset || c > uuOffset+uuMask+1 {
+					return nil, fmt.Errorf("uuencode character %q", c)
+				}
+
+				v[k] = uuValue(c)
+			}
+
+			chunk := []byte{v[0]<<2 | v[1]>>4, v[1]<<4 | v[2]>>2, v[2]<<6 | v[3]}
+			if j+3 > n {
+				chunk = chunk[:n-j]
+			}
+
+			out = append(out, chunk...)
+		}
+	}
+
+	return out, nil
+}
+
+// uuValue returns the 6-bit value of a UUencode character.
+func uuValue(c byte) byte {
+	return (c - uuOffset) & uuMask
+}
@@ -0,0 +1,148 @@
+package cfw_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleDecrypt() {
+	s := cfw.Encrypt("hello world", "key", cfw.EncodeHex)
+	fmt.Println(s)
+	d, _ := cfw.Decrypt(s, "key", cfw.EncodeHex)
+	fmt.Println(d)
+	// Output: 518E771F08ED44DA5FD517
+	// hello world
+}
+
+func ExampleMigrateGCM() {
+	aesKey := bytes.Repeat([]byte{1}, 32)
+	legacy := cfw.Encrypt("hello world", "key", cfw.EncodeUU)
+	s, _ := cfw.MigrateGCM(legacy, "key", cfw.EncodeUU, aesKey)
+	d, _ := cfw.DecryptGCM(s, aesKey)
+	fmt.Println(d)
+	// Output: hello world
+}
+
+func TestEncrypt(t *testing.T) {
+	t.Parallel()
+
+	// The vectors were not captured from Lucee or Adobe ColdFusion, they were computed by a separate
+	// line by line transcription of the setKey and transformByte methods of Lucee's CFMXCompat.java.
+	tests := []struct {
+		name string
+		key  string
+		enc  cfw.Encoding
+		want string
+	}{
+		{"uu", "key", cfw.EncodeUU, "+48YW'PCM1-I?U1< \n"},
+		{"base64", "key", cfw.EncodeBase64, "UY53HwjtRNpf1Rc="},
+		{"hex", "key", cfw.EncodeHex, "518E771F08ED44DA5FD517"},
+		{"empty key", "", cfw.EncodeHex, "880E9B5AF3D35009818A97"},
+		{"long key", "a much longer secret key", cfw.EncodeHex, "72A9938B96FBE0B449930B"},
+		{"unicode key", "ключ", cfw.EncodeHex, "731A10BF884ABA9A8BA083"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := cfw.Encrypt("hello world", tt.key, tt.enc)
+			if got != tt.want {
+				t.Errorf("Encrypt() = %q, want %q", got, tt.want)
+			}
+			d, err := cfw.Decrypt(got, tt.key, tt.enc)
+			if err != nil || d != "hello world" {
+				t.Errorf("Decrypt() = %q, %v", d, err)
+			}
+		})
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("CFWheels ", 10)
+
+	tests := []struct {
+		name    string
+		s       string
+		enc     cfw.Encoding
+		want    string
+		wantErr error
+	}{
+		{"uu", "+48YW'PCM1-I?U1< \n", cfw.EncodeUU, "hello world", nil},
+		{"uu backtick", "+48YW'PCM1-I?U1<`", cfw.EncodeUU, "hello world", nil},
+		{"uu crlf", "+48YW'PCM1-I?U1< \r\n", cfw.EncodeUU, "hello world", nil},
+		{"uu multiline", cfw.Encrypt(long, "key", cfw.EncodeUU), cfw.EncodeUU, long, nil},
+		{"uu empty", "", cfw.EncodeUU, "", nil},
+		{"uu short", "+48YW", cfw.EncodeUU, "", cfw.ErrEncoding},
+		{"uu character", "#~~~~", cfw.EncodeUU, "", cfw.ErrEncoding},
+		{"base64", " UY53HwjtRNpf1Rc=\n", cfw.EncodeBase64, "hello world", nil},
+		{"base64 invalid", "f2FK*", cfw.EncodeBase64, "", cfw.ErrEncoding},
+		{"hex lowercase", "518e771f08ed44da5fd517", cfw.EncodeHex, "hello world", nil},
+		{"hex invalid", "7F6", cfw.EncodeHex, "", cfw.ErrEncoding},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := cfw.Decrypt(tt.s, "key", tt.enc)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Decrypt() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Decrypt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncryptUULines(t *testing.T) {
+	t.Parallel()
+
+	s := cfw.Encrypt(strings.Repeat("x", 90), "key", cfw.EncodeUU)
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+
+	if len(lines) != 3 || lines[0][0] != 'M' || lines[1][0] != 'M' || lines[2] != " " {
+		t.Errorf("Encrypt() = %q, want two full lines and an empty line", s)
+	}
+}
+
+func TestDecryptGCM(t *testing.T) {
+	t.Parallel()
+
+	aesKey := bytes.Repeat([]byte{1}, 16)
+
+	s, err := cfw.EncryptGCM("hello world", aesKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if again, _ := cfw.EncryptGCM("hello world", aesKey); again == s {
+		t.Errorf("EncryptGCM() = %q, reuses the nonce", s)
+	}
+
+	if d, err := cfw.DecryptGCM(s, aesKey); err != nil || d != "hello world" {
+		t.Errorf("DecryptGCM() = %q, %v", d, err)
+	}
+
+	if _, err := cfw.DecryptGCM(s, bytes.Repeat([]byte{2}, 16)); err == nil {
+		t.Error("DecryptGCM() with the wrong key, want an error")
+	}
+
+	if _, err := cfw.DecryptGCM("AAAA", aesKey); !errors.Is(err, cfw.ErrEncoding) {
+		t.Errorf("DecryptGCM() error = %v, want %v", err, cfw.ErrEncoding)
+	}
+
+	if _, err := cfw.EncryptGCM("hello world", []byte("short")); !errors.Is(err, cfw.ErrGCMKey) {
+		t.Errorf("EncryptGCM() error = %v, want %v", err, cfw.ErrGCMKey)
+	}
+
+	if _, err := cfw.MigrateGCM("7F6", "key", cfw.EncodeHex, aesKey); !errors.Is(err, cfw.ErrEncoding) {
+		t.Errorf("MigrateGCM() error = %v, want %v", err, cfw.ErrEncoding)
+	}
+}
@@ -0,0 +1,446 @@
+package cfw
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+)
+
+// The default masks of the CFML functions, used when the mask is empty.
+const (
+	DateMask     = "dd-mmm-yy"
+	TimeMask     = "hh:mm tt"
+	DateTimeMask = "dd-mmm-yyyy HH:nn:ss"
+)
+
+// DateOptions changes the text returned by DateFormatWith, TimeFormatWith and DateTimeFormatWith.
+type DateOptions struct {
+	// Locale is the language of the month and day names, which defaults to English.
+	// The supported languages are English, Dutch, French, German, Italian, Portuguese and Spanish,
+	// and any other language uses English.
+	Locale language.Tag
+}
+
+// DateFormat returns t formatted using a CFML date mask, such as "mmm d, yyyy" or "dddd, dd/mm/yy".
+// An empty mask uses DateMask.
+//
+// The mask is case-insensitive and can be one of the presets short, medium, long or full,
+// or a combination of:
+//
+//	d     day of the month
+//	dd    day of the month with a leading zero
+//	ddd   abbreviated day of the week
+//	dddd  day of the week
+//	m     month
+//	mm    month with a leading zero
+//	mmm   abbreviated month name
+//	mmmm  month name
+//	y     year as the last two digits
+//	yy    year as the last two digits with a leading zero
+//	yyyy  year
+//	gg    period or era, AD or BC
+//
+// Any other text in the mask is kept as is, and text enclosed in single quotes is never treated as a mask.
+func DateFormat(t time.Time, mask string) string {
+	return DateFormatWith(t, mask, DateOptions{})
+}
+
+// DateFormatWith is the same as DateFormat, except the month and day names can be localized using opts.
+func DateFormatWith(t time.Time, mask string, opts DateOptions) string {
+	if mask == "" {
+		mask = DateMask
+	}
+
+	switch strings.ToLower(strings.TrimSpace(mask)) {
+	case "short":
+		mask = "m/d/yy"
+	case "medium":
+		mask = "mmm d, yyyy"
+	case "long":
+		mask = "mmmm d, yyyy"
+	case "full":
+		mask = "dddd, mmmm d, yyyy"
+	}
+
+	return formatMask(t, mask, dateElems, true, localize(opts.Locale))
+}
+
+// TimeFormat returns t formatted using a CFML time mask, such as "hh:mm tt" or "HH:mm:ss".
+// An empty mask uses TimeMask.
+//
+// The mask can be one of the presets short, medium, long or full, or a combination of:
+//
+//	h     hour of the 12-hour clock
+//	hh    hour of the 12-hour clock with a leading zero
+//	H     hour of the 24-hour clock
+//	HH    hour of the 24-hour clock with a leading zero
+//	m     minutes
+//	mm    minutes with a leading zero
+//	s     seconds
+//	ss    seconds with a leading zero
+//	l     milliseconds
+//	t     A or P
+//	tt    AM or PM
+//	z     time zone abbreviation
+//
+// Other than the hours, the mask is case-insensitive.
+// Any other text in the mask is kept as is, and text enclosed in single quotes is never treated as a mask.
+func TimeFormat(t time.Time, mask string) string {
+	return TimeFormatWith(t, mask, DateOptions{})
+}
+
+// TimeFormatWith is the same as TimeFormat, except the text can be localized using opts.
+// The time masks contain no names, so the locale is currently unused.
+func TimeFormatWith(t time.Time, mask string, opts DateOptions) string {
+	if mask == "" {
+		mask = TimeMask
+	}
+
+	switch strings.ToLower(strings.TrimSpace(mask)) {
+	case "short":
+		mask = "h:mm tt"
+	case "medium":
+		mask = "h:mm:ss tt"
+	case "long", "full":
+		mask = "h:mm:ss tt z"
+	}
+
+	return formatMask(t, mask, timeElems, false, localize(opts.Locale))
+}
+
+// DateTimeFormat returns t formatted using a CFML date and time mask, such as "mmm d, yyyy h:nn tt".
+// An empty mask uses DateTimeMask.
+//
+// The mask can be one of the presets short, medium, long or full,
+// or a combination of the DateFormat date masks and the TimeFormat time masks, except that
+// n and nn are the minutes, as m and mm are the month.
+// The mask also accepts:
+//
+//	EEE   abbreviated day of the week
+//	EEEE  day of the week
+//	k     hour of the 24-hour clock from 1 to 24
+//	K     hour of the 12-hour clock from 0 to 11
+//	Z     time zone offset, such as -0700
+//
+// Any other text in the mask is kept as is, and text enclosed in single quotes is never treated as a mask.
+func DateTimeFormat(t time.Time, mask string) string {
+	return DateTimeFormatWith(t, mask, DateOptions{})
+}
+
+// DateTimeFormatWith is the same as DateTimeFormat, except the month and day names can be localized using opts.
+func DateTimeFormatWith(t time.Time, mask string, opts DateOptions) string {
+	if mask == "" {
+		mask = DateTimeMask
+	}
+
+	switch strings.ToLower(strings.TrimSpace(mask)) {
+	case "short":
+		mask = "m/d/yy h:nn tt"
+	case "medium":
+		mask = "mmm d, yyyy h:nn:ss tt"
+	case "long":
+		mask = "mmmm d, yyyy h:nn:ss tt z"
+	case "full":
+		mask = "dddd, mmmm d, yyyy h:nn:ss tt z"
+	}
+
+	return formatMask(t, mask, dateTimeElems, false, localize(opts.Locale))
+}
+
+// eraYear returns the year of t in its era, where Go's year 0 is 1 BC and year -44 is 45 BC.
+func eraYear(t time.Time) int {
+	if y := t.Year(); y < 1 {
+		return 1 - y
+	}
+
+	return t.Year()
+}
+
+// maskElem returns the text of a mask element.
+type maskElem func(t time.Time, l *locale) string
+
+// maskElems are the mask elements of a CFML function, with the longest element of each letter listed first.
+type maskElems map[rune][]struct {
+	mask string
+	fn   maskElem
+}
+
+var (
+	tDay      = func(t time.Time, _ *locale) string { return strconv.Itoa(t.Day()) }
+	tDay2     = func(t time.Time, _ *locale) string { return pad(t.Day()) }
+	tWeekday3 = func(t time.Time, l *locale) string { return l.weekdaysAbbr[t.Weekday()] }
+	tWeekday  = func(t time.Time, l *locale) string { return l.weekdays[t.Weekday()] }
+	tMonth    = func(t time.Time, _ *locale) string { return strconv.Itoa(int(t.Month())) }
+	tMonth2   = func(t time.Time, _ *locale) string { return pad(int(t.Month())) }
+	tMonth3   = func(t time.Time, l *locale) string { return l.monthsAbbr[t.Month()-1] }
+	tMonth4   = func(t time.Time, l *locale) string { return l.months[t.Month()-1] }
+	tYear     = func(t time.Time, _ *locale) string { return strconv.Itoa(eraYear(t) % century) }
+	tYear2    = func(t time.Time, _ *locale) string { return pad(eraYear(t) % century) }
+	tYear4    = func(t time.Time, _ *locale) string { return strconv.Itoa(eraYear(t)) }
+	tEra      = func(t time.Time, _ *locale) string {
+		if t.Year() < 1 {
+			return "BC"
+		}
+
+		return "AD"
+	}
+	tHour12  = func(t time.Time, _ *locale) string { return strconv.Itoa(hour12(t)) }
+	tHour122 = func(t time.Time, _ *locale) string { return pad(hour12(t)) }
+	tHour    = func(t time.Time, _ *locale) string { return strconv.Itoa(t.Hour()) }
+	tHour2   = func(t time.Time, _ *locale) string { return pad(t.Hour()) }
+	tMinute  = func(t time.Time, _ *locale) string { return strconv.Itoa(t.Minute()) }
+	tMinute2 = func(t time.Time, _ *locale) string { return pad(t.Minute()) }
+	tSecond  = func(t time.Time, _ *locale) string { return strconv.Itoa(t.Second()) }
+	tSecond2 = func(t time.Time, _ *locale) string { return pad(t.Second()) }
+	tMilli   = func(t time.Time, _ *locale) string { return t.Format(".000")[1:] }
+	tAP      = func(t time.Time, _ *locale) string { return t.Format("PM")[:1] }
+	tAMPM    = func(t time.Time, _ *locale) string { return t.Format("PM") }
+	tZone    = func(t time.Time, _ *locale) string { return t.Format("MST") }
+	tOffset  = func(t time.Time, _ *locale) string { return t.Format("-0700") }
+	tHour24  = func(t time.Time, _ *locale) string {
+		if t.Hour() == 0 {
+			return strconv.Itoa(fullday)
+		}
+
+		return strconv.Itoa(t.Hour())
+	}
+	tHour242 = func(t time.Time, _ *locale) string {
+		if t.Hour() == 0 {
+			return strconv.Itoa(fullday)
+		}
+
+		return pad(t.Hour())
+	}
+	tHour11  = func(t time.Time, _ *locale) string { return strconv.Itoa(t.Hour() % halfday) }
+	tHour112 = func(t time.Time, _ *locale) string { return pad(t.Hour() % halfday) }
+)
+
+var dateElems = maskElems{
+	'd': {{"dddd", tWeekday}, {"ddd", tWeekday3}, {"dd", tDay2}, {"d", tDay}},
+	'g': {{"gg", tEra}},
+	'm': {{"mmmm", tMonth4}, {"mmm", tMonth3}, {"mm", tMonth2}, {"m", tMonth}},
+	'y': {{"yyyy", tYear4}, {"yy", tYear2}, {"y", tYear}},
+}
+
+var timeElems = maskElems{
+	'h': {{"hh", tHour122}, {"h", tHour12}},
+	'H': {{"HH", tHour2}, {"H", tHour}},
+	'm': {{"mm", tMinute2}, {"m", tMinute}},
+	'M': {{"MM", tMinute2}, {"M", tMinute}},
+	's': {{"ss", tSecond2}, {"s", tSecond}},
+	'S': {{"SS", tSecond2}, {"S", tSecond}},
+	'l': {{"l", tMilli}},
+	'L': {{"L", tMilli}},
+	't': {{"tt", tAMPM}, {"t", tAP}},
+	'T': {{"TT", tAMPM}, {"T", tAP}},
+	'z': {{"z", tZone}},
+	'Z': {{"Z", tOffset}},
+}
+
+var dateTimeElems = maskElems{
+	'd': dateElems['d'],
+	'D': {{"DDDD", tWeekday}, {"DDD", tWeekday3}, {"DD", tDay2}, {"D", tDay}},
+	'E': {{"EEEE", tWeekday}, {"EEE", tWeekday3}},
+	'g': dateElems['g'],
+	'G': {{"GG", tEra}, {"G", tEra}},
+	'm': dateElems['m'],
+	'M': {{"MMMM", tMonth4}, {"MMM", tMonth3}, {"MM", tMonth2}, {"M", tMonth}},
+	'y': dateElems['y'],
+	'Y': {{"YYYY", tYear4}, {"YY", tYear2}, {"Y", tYear}},
+	'h': timeElems['h'],
+	'H': timeElems['H'],
+	'k': {{"kk", tHour242}, {"k", tHour24}},
+	'K': {{"KK", tHour112}, {"K", tHour11}},
+	'n': {{"nn", tMinute2}, {"n", tMinute}},
+	'N': {{"NN", tMinute2}, {"N", tMinute}},
+	's': timeElems['s'],
+	'S': timeElems['S'],
+	'l': timeElems['l'],
+	'L': timeElems['L'],
+	't': timeElems['t'],
+	'T': timeElems['T'],
+	'z': timeElems['z'],
+	'Z': timeElems['Z'],
+}
+
+const (
+	century = 100
+	halfday = 12
+	fullday = 24
+)
+
+// formatMask replaces the mask elements with the text of t.
+// When fold is true, the mask elements are matched case-insensitively using the lowercase elements.
+func formatMask(t time.Time, mask string, elems maskElems, fold bool, l *locale) string {
+	var b strings.Builder
+
+	b.Grow(len(mask) * 2)
+
+	for i := 0; i < len(mask); {
+		r, size := utf8.DecodeRuneInString(mask[i:])
+		if r == '\'' {
+			i += quoted(&b, mask[i+size:]) + size
+
+			continue
+		}
+
+		if fold {
+			r = unicode.ToLower(r)
+		}
+
+		matched := false
+
+		for _, e := range elems[r] {
+			if has(mask[i:], e.mask, fold) {
+				b.WriteString(e.fn(t, l))
+				i += len(e.mask)
+				matched = true
+
+				break
+			}
+		}
+
+		if !matched {
+			b.WriteString(mask[i : i+size])
+			i += size
+		}
+	}
+
+	return b.String()
+}
+
+// has reports whether s begins with the mask element.
+func has(s, elem string, fold bool) bool {
+	if !fold {
+		return strings.HasPrefix(s, elem)
+	}
+
+	return len(s) >= len(elem) && strings.EqualFold(s[:len(elem)], elem)
+}
+
+// quoted writes the literal text of s up to the closing single quote, where two single quotes are a quote character.
+// It returns the number of bytes used, including the closing quote.
+func quoted(b *strings.Builder, s string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\'' {
+			b.WriteByte(s[i])
+
+			continue
+		}
+
+		if i+1 < len(s) && s[i+1] == '\'' {
+			b.WriteByte('\'')
+			i++
+
+			continue
+		}
+
+		return i + 1
+	}
+
+	return len(s)
+}
+
+func hour12(t time.Time) int {
+	if h := t.Hour() % halfday; h != 0 {
+		return h
+	}
+
+	return halfday
+}
+
+func pad(i int) string {
+	if i < decimal && i >= 0 {
+		return "0" + strconv.Itoa(i)
+	}
+
+	return strconv.Itoa(i)
+}
+
+// locale contains the month and day names of a language.
+type locale struct {
+	months       [12]string
+	monthsAbbr   [12]string
+	weekdays     [7]string
+	weekdaysAbbr [7]string
+}
+
+// The abbreviated names follow the Java locale data used by the CFML engines.
+var locales = []locale{
+	{
+		months: [12]string{"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+		monthsAbbr:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		weekdays:     [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		weekdaysAbbr: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	},
+	{
+		months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember"},
+		monthsAbbr:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		weekdays:     [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		weekdaysAbbr: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	},
+	{
+		months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		monthsAbbr:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+		weekdays:     [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		weekdaysAbbr: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	},
+	{
+		months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		monthsAbbr: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin",
+			"juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		weekdays:     [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		weekdaysAbbr: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	},
+	{
+		months: [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno",
+			"luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		monthsAbbr:   [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		weekdays:     [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		weekdaysAbbr: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	},
+	{
+		months: [12]string{"januari", "februari", "maart", "april", "mei", "juni",
+			"juli", "augustus", "september", "oktober", "november", "december"},
+		monthsAbbr:   [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		weekdays:     [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		weekdaysAbbr: [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+	},
+	{
+		months: [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+			"julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		monthsAbbr: [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		weekdays: [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira",
+			"quinta-feira", "sexta-feira", "sábado"},
+		weekdaysAbbr: [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+	},
+}
+
+// localeMatcher matches a language to the locales, which must be listed in the same order.
+var localeMatcher = language.NewMatcher([]language.Tag{
+	language.English,
+	language.German,
+	language.Spanish,
+	language.French,
+	language.Italian,
+	language.Dutch,
+	language.Portuguese,
+})
+
+// localize returns the locale of the language, or English if the language is not supported.
+func localize(tag language.Tag) *locale {
+	_, i, conf := localeMatcher.Match(tag)
+	if conf == language.No || i < 0 || i >= len(locales) {
+		return &locales[0]
+	}
+
+	return &locales[i]
+}
@@ -0,0 +1,190 @@
+package cfw_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bengarrett/cfw"
+	"golang.org/x/text/language"
+)
+
+func ExampleDateFormat() {
+	t := time.Date(2026, time.January, 5, 15, 4, 5, 0, time.UTC)
+	fmt.Println(cfw.DateFormat(t, "mmm d, yyyy"))
+	fmt.Println(cfw.DateFormat(t, "full"))
+	fmt.Println(cfw.DateFormatWith(t, "dddd d mmmm yyyy", cfw.DateOptions{Locale: language.German}))
+	// Output: Jan 5, 2026
+	// Monday, January 5, 2026
+	// Montag 5 Januar 2026
+}
+
+func ExampleTimeFormat() {
+	t := time.Date(2026, time.January, 5, 15, 4, 5, 0, time.UTC)
+	fmt.Println(cfw.TimeFormat(t, "hh:mm tt"))
+	fmt.Println(cfw.TimeFormat(t, "HH:mm:ss"))
+	// Output: 03:04 PM
+	// 15:04:05
+}
+
+func ExampleDateTimeFormat() {
+	t := time.Date(2026, time.January, 5, 15, 4, 5, 0, time.UTC)
+	fmt.Println(cfw.DateTimeFormat(t, "yyyy-mm-dd'T'HH:nn:ssZ"))
+	// Output: 2026-01-05T15:04:05+0000
+}
+
+func TestDateFormat(t *testing.T) {
+	t.Parallel()
+
+	d := time.Date(2026, time.March, 8, 0, 7, 9, 0, time.UTC)
+	tests := []struct {
+		name string
+		mask string
+		want string
+	}{
+		{"default", "", "08-Mar-26"},
+		{"short", "short", "3/8/26"},
+		{"medium", "Medium", "Mar 8, 2026"},
+		{"long", "long", "March 8, 2026"},
+		{"full", "full", "Sunday, March 8, 2026"},
+		{"uppercase", "MMMM D, YYYY", "March 8, 2026"},
+		{"mixed case", "Mmm dD", "Mar 08"},
+		{"digits", "dd/mm/yy y", "08/03/26 26"},
+		{"day names", "ddd dddd", "Sun Sunday"},
+		{"era", "yyyy gg", "2026 AD"},
+		{"literal", "'day' d 'of' mmmm", "day 8 of March"},
+		{"quote", "'it''s' d", "it's 8"},
+		{"unclosed", "d 'mmm", "8 mmm"},
+		{"text", "d/m €", "8/3 €"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.DateFormat(d, tt.mask); got != tt.want {
+				t.Errorf("DateFormat(%q) = %q, want %q", tt.mask, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateFormatEra(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		year int
+		mask string
+		want string
+	}{
+		{"ad", 1, "yyyy gg", "1 AD"},
+		{"1 bc", 0, "yyyy gg", "1 BC"},
+		{"45 bc", -44, "yyyy gg", "45 BC"},
+		{"short", -44, "yy y", "45 45"},
+		{"long ago", -1233, "mmm yyyy GG", "Mar 1234 BC"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d := time.Date(tt.year, time.March, 15, 0, 0, 0, 0, time.UTC)
+			if got := cfw.DateFormat(d, tt.mask); got != tt.want {
+				t.Errorf("DateFormat(%q) = %q, want %q", tt.mask, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateFormatWith(t *testing.T) {
+	t.Parallel()
+
+	d := time.Date(2026, time.March, 8, 0, 7, 9, 0, time.UTC)
+	tests := []struct {
+		name   string
+		locale language.Tag
+		want   string
+	}{
+		{"default", language.Und, "Sun 8 Mar, Sunday 8 March"},
+		{"english", language.BritishEnglish, "Sun 8 Mar, Sunday 8 March"},
+		{"german", language.German, "So 8 Mär, Sonntag 8 März"},
+		{"spanish", language.LatinAmericanSpanish, "dom 8 mar, domingo 8 marzo"},
+		{"french", language.CanadianFrench, "dim. 8 mars, dimanche 8 mars"},
+		{"italian", language.Italian, "dom 8 mar, domenica 8 marzo"},
+		{"dutch", language.Dutch, "zo 8 mrt, zondag 8 maart"},
+		{"portuguese", language.BrazilianPortuguese, "dom 8 mar, domingo 8 março"},
+		{"unsupported", language.Japanese, "Sun 8 Mar, Sunday 8 March"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := cfw.DateFormatWith(d, "ddd d mmm, dddd d mmmm", cfw.DateOptions{Locale: tt.locale})
+			if got != tt.want {
+				t.Errorf("DateFormatWith(%v) = %q, want %q", tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeFormat(t *testing.T) {
+	t.Parallel()
+
+	pm := time.Date(2026, time.January, 5, 15, 4, 5, 123000000, time.UTC)
+	am := time.Date(2026, time.March, 8, 0, 7, 9, 0, time.FixedZone("EST", -5*60*60))
+	tests := []struct {
+		name   string
+		mask   string
+		wantPM string
+		wantAM string
+	}{
+		{"default", "", "03:04 PM", "12:07 AM"},
+		{"short", "short", "3:04 PM", "12:07 AM"},
+		{"medium", "medium", "3:04:05 PM", "12:07:09 AM"},
+		{"long", "long", "3:04:05 PM UTC", "12:07:09 AM EST"},
+		{"24-hour", "HH:mm:ss.l", "15:04:05.123", "00:07:09.000"},
+		{"12-hour", "h t", "3 P", "12 A"},
+		{"uppercase", "hh:MM TT", "03:04 PM", "12:07 AM"},
+		{"literal", "H'h'", "15h", "0h"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.TimeFormat(pm, tt.mask); got != tt.wantPM {
+				t.Errorf("TimeFormat(%q) = %q, want %q", tt.mask, got, tt.wantPM)
+			}
+			if got := cfw.TimeFormat(am, tt.mask); got != tt.wantAM {
+				t.Errorf("TimeFormat(%q) = %q, want %q", tt.mask, got, tt.wantAM)
+			}
+		})
+	}
+}
+
+func TestDateTimeFormat(t *testing.T) {
+	t.Parallel()
+
+	d := time.Date(2026, time.March, 8, 0, 7, 9, 0, time.FixedZone("EST", -5*60*60))
+	tests := []struct {
+		name string
+		mask string
+		want string
+	}{
+		{"default", "", "08-Mar-2026 00:07:09"},
+		{"short", "short", "3/8/26 12:07 AM"},
+		{"medium", "medium", "Mar 8, 2026 12:07:09 AM"},
+		{"long", "long", "March 8, 2026 12:07:09 AM EST"},
+		{"full", "full", "Sunday, March 8, 2026 12:07:09 AM EST"},
+		{"minutes", "m/d/yyyy n nn", "3/8/2026 7 07"},
+		{"java", "EEE, dd MMM yyyy HH:nn:ss Z", "Sun, 08 Mar 2026 00:07:09 -0500"},
+		{"hours", "k kk K KK", "24 24 0 00"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.DateTimeFormat(d, tt.mask); got != tt.want {
+				t.Errorf("DateTimeFormat(%q) = %q, want %q", tt.mask, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package cfw
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	paragraph   = "<P>\r\n"
+	replacement = "&#xfffd;"
+)
+
+// htmlEdit replaces the characters escaped by the CFML HTMLEditFormat function.
+var htmlEdit = strings.NewReplacer(
+	`&`, "&amp;",
+	`<`, "&lt;",
+	`>`, "&gt;",
+	`"`, "&quot;",
+)
+
+// ParagraphFormat replaces the newlines in s with spaces and HTML paragraph tags.
+// A single newline is replaced with a space, while the second of two newlines is replaced with
+// an uppercase <P> tag and a CRLF, so a blank line between two paragraphs becomes " <P>\r\n".
+// The paragraphs are never closed, and the text is not HTML escaped.
+// A CR is a newline, but a CRLF is a single newline.
+//
+// This function is a port of the CFML ParagraphFormat function.
+func ParagraphFormat(s string) string {
+	var b strings.Builder
+
+	b.Grow(len(s))
+
+	newline := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\r' && i+1 < len(s) && s[i+1] == '\n' {
+			continue
+		}
+
+		if c != '\r' && c != '\n' {
+			b.WriteByte(c)
+
+			newline = false
+
+			continue
+		}
+
+		if newline {
+			b.WriteString(paragraph)
+
+			newline = false
+
+			continue
+		}
+
+		b.WriteByte(' ')
+
+		newline = true
+	}
+
+	return b.String()
+}
+
+// Wrap inserts newlines into s so that each line has at most n characters.
+// A line is broken at its last space or tab, where the whitespace around the break is replaced by the newline,
+// while a word that is longer than n characters is broken at n characters.
+// No empty lines are added, even when a word exactly fills a line.
+// When strip is true, the existing newlines are replaced with spaces before the text is wrapped,
+// otherwise each existing line is wrapped on its own.
+// The text is returned unchanged if n is less than 1.
+//
+// This function is a port of the CFML Wrap function, except the inserted newlines are always LF.
+func Wrap(s string, n int, strip bool) string {
+	if n < 1 {
+		return s
+	}
+
+	if strip {
+		s = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(s)
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		cr := strings.HasSuffix(line, "\r")
+		line = wrapLine(strings.TrimSuffix(line, "\r"), n)
+
+		if cr {
+			line += "\r"
+		}
+
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// wrapLine breaks a line without newlines into lines of at most n characters.
+func wrapLine(s string, n int) string {
+	var b strings.Builder
+
+	for utf8.RuneCountInString(s) > n {
+		cut := offset(s, n, Runes)
+
+		i := cut
+		if s[cut] != ' ' && s[cut] != '\t' {
+			// break at the last whitespace, otherwise cut the word
+			if j := strings.LastIndexAny(s[:cut], " \t"); j >= 0 {
+				i = j
+			}
+		}
+
+		line := strings.TrimRight(s[:i], " \t")
+		if line == "" {
+			// never break before a line that is only whitespace, which would leave it empty
+			s = strings.TrimLeft(s, " \t")
+
+			continue
+		}
+
+		b.WriteString(line)
+		b.WriteByte('\n')
+
+		s = strings.TrimLeft(s[i:], " \t")
+	}
+
+	b.WriteString(s)
+
+	return b.String()
+}
+
+// HTMLEditFormat replaces the <, >, & and " characters in s with their HTML character references.
+// Unlike html.EscapeString, the ' character is not escaped.
+//
+// This function is a port of the CFML HTMLEditFormat function.
+func HTMLEditFormat(s string) string {
+	return htmlEdit.Replace(s)
+}
+
+// EncodeForHTML encodes s for use in HTML content, the same as the CFML EncodeForHTML function
+// that uses the OWASP ESAPI encoder.
+// The letters and digits of ASCII, the space and the , . - _ characters are kept as is,
+// while all other characters are replaced with their HTML 4.01 named character reference,
+// such as &amp; or &eacute;, or otherwise a lowercase hexadecimal character reference, such as &#x27;.
+// The control characters other than the tab, LF and CR, and any invalid UTF-8, are replaced with &#xfffd;.
+func EncodeForHTML(s string) string {
+	var b strings.Builder
+
+	b.Grow(len(s))
+
+	for _, r := range s {
+		switch {
+		case r < utf8.RuneSelf && immune(byte(r)):
+			b.WriteRune(r)
+		case r == utf8.RuneError,
+			r < ' ' && r != '\t' && r != '\n' && r != '\r',
+			r >= 0x7F && r <= 0x9F:
+			b.WriteString(replacement)
+		case entities[r] != "":
+			b.WriteString("&" + entities[r] + ";")
+		default:
+			b.WriteString("&#x" + strconv.FormatInt(int64(r), hexadecimal) + ";")
+		}
+	}
+
+	return b.String()
+}
+
+// immune reports whether the ASCII character is not encoded by EncodeForHTML.
+func immune(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	case c == ' ', c == ',', c == '.', c == '-', c == '_':
+		return true
+	default:
+		return false
+	}
+}
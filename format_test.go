@@ -0,0 +1,138 @@
+package cfw_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleParagraphFormat() {
+	fmt.Printf("%q\n", cfw.ParagraphFormat("line one\nline two\n\nparagraph two"))
+	// Output: "line one line two <P>\r\nparagraph two"
+}
+
+func ExampleWrap() {
+	fmt.Println(cfw.Wrap("The quick brown fox jumps over the lazy dog", 10, false))
+	// Output: The quick
+	// brown fox
+	// jumps over
+	// the lazy
+	// dog
+}
+
+func ExampleEncodeForHTML() {
+	fmt.Println(cfw.HTMLEditFormat(`<a href="/">Tom's café</a>`))
+	fmt.Println(cfw.EncodeForHTML(`<a href="/">Tom's café</a>`))
+	// Output: &lt;a href=&quot;/&quot;&gt;Tom's café&lt;/a&gt;
+	// &lt;a href&#x3d;&quot;&#x2f;&quot;&gt;Tom&#x27;s caf&eacute;&lt;&#x2f;a&gt;
+}
+
+func TestParagraphFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"empty", "", ""},
+		{"none", "hello world", "hello world"},
+		{"newline", "a\nb", "a b"},
+		{"crlf", "a\r\nb", "a b"},
+		{"cr", "a\rb", "a b"},
+		{"paragraph", "a\n\nb", "a <P>\r\nb"},
+		{"crlf paragraph", "a\r\n\r\nb", "a <P>\r\nb"},
+		{"three", "a\n\n\nb", "a <P>\r\n b"},
+		{"four", "a\n\n\n\nb", "a <P>\r\n <P>\r\nb"},
+		{"trailing", "a\n\n", "a <P>\r\n"},
+		{"markup", "<b>a</b>\n\nb", "<b>a</b> <P>\r\nb"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.ParagraphFormat(tt.s); got != tt.want {
+				t.Errorf("ParagraphFormat(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		s     string
+		n     int
+		strip bool
+		want  string
+	}{
+		{"empty", "", 5, false, ""},
+		{"short", "abc", 5, false, "abc"},
+		{"exact", "abcde", 5, false, "abcde"},
+		{"zero", "abc def", 0, false, "abc def"},
+		{"space at limit", "abcde fgh", 5, false, "abcde\nfgh"},
+		{"last space", "ab cdef", 5, false, "ab\ncdef"},
+		{"tab", "ab\tcdef", 5, false, "ab\ncdef"},
+		{"hard break", "abcdefghijkl", 5, false, "abcde\nfghij\nkl"},
+		{"long word", "a abcdefgh", 5, false, "a\nabcde\nfgh"},
+		{"lines", "abc def\nghi jkl", 5, false, "abc\ndef\nghi\njkl"},
+		{"crlf", "abc def\r\nghi", 5, false, "abc\ndef\r\nghi"},
+		{"strip", "ab\ncd\r\nef", 5, true, "ab cd\nef"},
+		{"keep", "ab\ncd\r\nef", 5, false, "ab\ncd\r\nef"},
+		{"runes", "été été", 3, false, "été\nété"},
+		{"leading space", " abcdef", 3, false, "abc\ndef"},
+		{"word fills line", "abcde  fghij", 5, false, "abcde\nfghij"},
+		{"spaces fill line", "ab      cd", 3, false, "ab\ncd"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.Wrap(tt.s, tt.n, tt.strip); got != tt.want {
+				t.Errorf("Wrap(%q, %d, %t) = %q, want %q", tt.s, tt.n, tt.strip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLEditFormat(t *testing.T) {
+	t.Parallel()
+
+	const s = `<p class="x">Tom & Jerry's</p>`
+	if got, want := cfw.HTMLEditFormat(s), `&lt;p class=&quot;x&quot;&gt;Tom &amp; Jerry's&lt;/p&gt;`; got != want {
+		t.Errorf("HTMLEditFormat(%q) = %q, want %q", s, got, want)
+	}
+}
+
+func TestEncodeForHTML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"empty", "", ""},
+		{"immune", "Hello, World. a-b_c 123", "Hello, World. a-b_c 123"},
+		{"named", `&<>"`, "&amp;&lt;&gt;&quot;"},
+		{"hex", "'/=()", "&#x27;&#x2f;&#x3d;&#x28;&#x29;"},
+		{"latin", "café ©", "caf&eacute; &copy;"},
+		{"greek", "αβ", "&alpha;&beta;"},
+		{"emoji", "🦊", "&#x1f98a;"},
+		{"whitespace", "\t\n\r", "&#x9;&#xa;&#xd;"},
+		{"control", "\x00\x1b\u0085", "&#xfffd;&#xfffd;&#xfffd;"},
+		{"invalid", "a\xffb", "a&#xfffd;b"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.EncodeForHTML(tt.s); got != tt.want {
+				t.Errorf("EncodeForHTML(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
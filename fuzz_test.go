@@ -0,0 +1,357 @@
+package cfw_test
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bengarrett/cfw"
+	"golang.org/x/text/language"
+)
+
+// The fuzz targets check the invariants of the helpers, while their seed corpora are in testdata/fuzz.
+// Run a target with: go test -run=^$ -fuzz=^FuzzTruncate$ -fuzztime=30s
+
+const fuzzHTML = `<p>This is <a href="/test" onclick="x()">a test</a> to see if <em>this works</em> or not.</p>`
+
+// valid fails the test if the output of the helper is not valid UTF-8, when all the inputs are valid UTF-8.
+func valid(t *testing.T, name, out string, in ...string) {
+	t.Helper()
+
+	for _, s := range in {
+		if !utf8.ValidString(s) {
+			return
+		}
+	}
+
+	if !utf8.ValidString(out) {
+		t.Errorf("%s(%q) = %q, is not valid UTF-8", name, in, out)
+	}
+}
+
+func FuzzAutoLink(f *testing.F) {
+	f.Add("Visit www.example.com or mail me@example.com", 0)
+	f.Add(`<a href="https://example.com">https://example.com</a> http://x.y/?a=1&amp;b=2&gt;`, 1)
+	f.Fuzz(func(t *testing.T, s string, mode int) {
+		valid(t, "AutoLink", cfw.AutoLink(s, cfw.Link(mode)), s)
+	})
+}
+
+func FuzzNumberFormat(f *testing.F) {
+	f.Add(1234.567, "9,999.99")
+	f.Add(-0.5, "L$(000^00)")
+	f.Fuzz(func(t *testing.T, x float64, mask string) {
+		valid(t, "NumberFormat", cfw.NumberFormat(x, mask), mask)
+		valid(t, "DollarFormat", cfw.DollarFormat(x))
+	})
+}
+
+func FuzzObfuscate(f *testing.F) {
+	f.Add(int64(1))
+	f.Add(int64(99))
+	f.Add(int64(15765))
+	f.Add(int64(999999999))
+	f.Add(int64(999999999999))
+	f.Add(int64(1234567890123457))
+	f.Add(int64(999999999999999999))
+	f.Add(int64(9223372036854775807))
+	f.Fuzz(func(t *testing.T, i int64) {
+		s := strconv.FormatInt(i, 10)
+		o := cfw.Obfuscate(s)
+		valid(t, "Obfuscate", o, s)
+
+		if i < 1 {
+			return
+		}
+
+		if d := cfw.DeObfuscate(o); d != s {
+			t.Errorf("DeObfuscate(Obfuscate(%q)) = %q, want %q", s, d, s)
+		}
+	})
+}
+
+func FuzzDateFormat(f *testing.F) {
+	f.Add(int64(0), "dddd, mmmm d, yyyy 'at' h:nn tt", "de")
+	f.Add(int64(-1<<40), "full", "fr")
+	f.Fuzz(func(t *testing.T, sec int64, mask, lang string) {
+		d := time.Unix(sec, 0).UTC()
+		tag, _ := language.Parse(lang)
+		opts := cfw.DateOptions{Locale: tag}
+		valid(t, "DateFormatWith", cfw.DateFormatWith(d, mask, opts), mask)
+		valid(t, "TimeFormatWith", cfw.TimeFormatWith(d, mask, opts), mask)
+		valid(t, "DateTimeFormatWith", cfw.DateTimeFormatWith(d, mask, opts), mask)
+	})
+}
+
+func FuzzDeObfuscate(f *testing.F) {
+	f.Add("eb77359232")
+	f.Add("9b1c6")
+	f.Add("hello world")
+	f.Fuzz(func(t *testing.T, s string) {
+		valid(t, "DeObfuscate", cfw.DeObfuscate(s), s)
+	})
+}
+
+func FuzzDeserializeJSON(f *testing.F) {
+	f.Add(`{"ROWCOUNT":2,"COLUMNS":["ID","NAME"],"DATA":{"ID":[1.0,2.0],"NAME":["Ada","Grace"]}}`)
+	f.Add(`{"COLUMNS":["ID"],"DATA":[[1.0],[2.0,3.0]]}`)
+	f.Fuzz(func(t *testing.T, s string) {
+		var v interface{}
+		if err := cfw.DeserializeJSON(s, &v); err != nil {
+			return
+		}
+
+		if q, ok := v.(cfw.Query); ok {
+			if _, err := cfw.SerializeJSONWith(q, cfw.JSONOptions{QueryByColumns: true}); err != nil {
+				t.Errorf("SerializeJSONWith(%q) error = %v", s, err)
+			}
+		}
+	})
+}
+
+func FuzzDeserializeWDDX(f *testing.F) {
+	f.Add("<wddxPacket version='1.0'><header/><data><recordset rowCount='2' fieldNames='ID'>" +
+		"<field name='ID'><number>1</number><number>2</number></field></recordset></data></wddxPacket>")
+	f.Add("<wddxPacket version='1.0'><header/><data><struct><var name='a'><string>b</string></var></struct></data></wddxPacket>")
+	f.Fuzz(func(t *testing.T, s string) {
+		var v interface{}
+		if err := cfw.DeserializeWDDX(s, &v); err != nil {
+			return
+		}
+
+		if _, err := cfw.SerializeWDDX(v); err != nil {
+			t.Errorf("SerializeWDDX(%q) error = %v", s, err)
+		}
+	})
+}
+
+func FuzzEncrypt(f *testing.F) {
+	f.Add("hello world", "key", uint8(0))
+	f.Add("CFWheels is a framework for ColdFusion, with a line longer than 45 bytes", "ключ", uint8(1))
+	f.Fuzz(func(t *testing.T, s, key string, enc uint8) {
+		e := cfw.Encoding(enc % 3)
+		if d, err := cfw.Decrypt(cfw.Encrypt(s, key, e), key, e); err != nil || d != s {
+			t.Errorf("Decrypt(Encrypt(%q, %q, %d)) = %q, %v", s, key, e, d, err)
+		}
+
+		_, _ = cfw.Decrypt(s, key, e)
+	})
+}
+
+func FuzzExcerpt(f *testing.F) {
+	f.Add("CFWheels: testing the excerpt view helper to see if it works or not.", "[more]", "excerpt view helper", 10, uint8(0))
+	f.Add("The quick brown 🦊 jumps over the lazy 🐕", "💬", "🦊", 3, uint8(3))
+	f.Add(fuzzHTML, "", "test", 5, uint8(4))
+	f.Fuzz(func(t *testing.T, s, replace, phrase string, n int, flags uint8) {
+		valid(t, "Excerpt", cfw.Excerpt(s, replace, phrase, n), s, replace, phrase)
+
+		opts := cfw.ExcerptOptions{
+			Replace:  replace,
+			Unit:     cfw.Unit(flags & 1),
+			Boundary: flags&2 != 0,
+			HTML:     flags&4 != 0,
+		}
+		got := cfw.ExcerptWith(s, phrase, n, opts)
+		valid(t, "ExcerptWith", got, s, replace, phrase)
+
+		if !opts.HTML && utf8.ValidString(s) && utf8.ValidString(phrase) &&
+			strings.Contains(s, phrase) && !strings.Contains(got, phrase) {
+			t.Errorf("ExcerptWith(%q, %q) = %q, is missing the phrase", s, phrase, got)
+		}
+	})
+}
+
+func FuzzHumanize(f *testing.F) {
+	f.Add("wheelsIsAFramework", "CFML")
+	f.Add("aURLVariable", "(")
+	f.Fuzz(func(t *testing.T, s, except string) {
+		valid(t, "Humanize", cfw.Humanize(s), s)
+		valid(t, "Humanize", cfw.Humanize(s, except), s)
+	})
+}
+
+func FuzzHyphenize(f *testing.F) {
+	f.Add("wheelsIsAFramework")
+	f.Add("myBlogPost ÉTÉ")
+	f.Fuzz(func(t *testing.T, s string) {
+		valid(t, "Hyphenize", cfw.Hyphenize(s), s)
+	})
+}
+
+func FuzzParagraphFormat(f *testing.F) {
+	f.Add("line one\nline two\r\n\r\nparagraph two\r")
+	f.Fuzz(func(t *testing.T, s string) {
+		valid(t, "ParagraphFormat", cfw.ParagraphFormat(s), s)
+		valid(t, "HTMLEditFormat", cfw.HTMLEditFormat(s), s)
+
+		if got := cfw.EncodeForHTML(s); !utf8.ValidString(got) || strings.ContainsAny(got, `<>"'`) {
+			t.Errorf("EncodeForHTML(%q) = %q, is unsafe", s, got)
+		}
+	})
+}
+
+func FuzzReverseInt(f *testing.F) {
+	f.Add(int64(12345))
+	f.Add(int64(-10))
+	f.Fuzz(func(t *testing.T, i int64) {
+		r, err := cfw.ReverseInt(int(i))
+		if err != nil || i < 0 || i%10 == 0 {
+			return
+		}
+
+		if rr, _ := cfw.ReverseInt(r); rr != int(i) {
+			t.Errorf("ReverseInt(ReverseInt(%d)) = %d", i, rr)
+		}
+	})
+}
+
+func FuzzSanitize(f *testing.F) {
+	f.Add(fuzzHTML)
+	f.Add(`<script>alert(1)</script><a href="javascript:alert(1)">x</a><img src=x onerror=alert(1)>`)
+	f.Fuzz(func(t *testing.T, s string) {
+		got := cfw.Sanitize(s, cfw.WheelsPolicy())
+		valid(t, "Sanitize", got, s)
+
+		if lower := strings.ToLower(got); strings.Contains(lower, "<script") || strings.Contains(lower, `="javascript:`) {
+			t.Errorf("Sanitize(%q) = %q, is unsafe", s, got)
+		}
+	})
+}
+
+func FuzzSimpleFormat(f *testing.F) {
+	f.Add("line one\nline two\r\n\r\nparagraph <b>two</b>", true, true)
+	f.Fuzz(func(t *testing.T, s string, wrap, escape bool) {
+		valid(t, "SimpleFormat", cfw.SimpleFormat(s, wrap, escape), s)
+	})
+}
+
+func FuzzStripTags(f *testing.F) {
+	f.Add(fuzzHTML, false, false)
+	f.Add(`<![CDATA[x]]><script>x</script><textarea><b>y</b></textarea>`, true, true)
+	f.Fuzz(func(t *testing.T, s string, decode, inline bool) {
+		got := cfw.StripTags(s)
+		valid(t, "StripTags", got, s)
+
+		r, err := io.ReadAll(cfw.NewStripTagsReader(strings.NewReader(s)))
+		if err != nil || string(r) != got {
+			t.Errorf("NewStripTagsReader(%q) = %q, %v, want %q", s, r, err, got)
+		}
+
+		opts := cfw.StripOptions{Decode: decode, Inline: inline}
+		valid(t, "StripTagsWith", cfw.StripTagsWith(s, opts), s)
+
+		if b := cfw.AppendStripTags(nil, []byte(s)); string(b) != got {
+			t.Errorf("AppendStripTags(%q) = %q, want %q", s, b, got)
+		}
+	})
+}
+
+func FuzzStripLinks(f *testing.F) {
+	f.Add(fuzzHTML, "img")
+	f.Add(`<A HREF="x">link</A><iframe><a>y</a></iframe>`, "iframe")
+	f.Fuzz(func(t *testing.T, s, name string) {
+		got := cfw.StripLinks(s)
+		valid(t, "StripLinks", got, s)
+
+		r, err := io.ReadAll(cfw.NewStripLinksReader(strings.NewReader(s)))
+		if err != nil || string(r) != got {
+			t.Errorf("NewStripLinksReader(%q) = %q, %v, want %q", s, r, err, got)
+		}
+
+		if b := cfw.AppendStripLinks(nil, []byte(s)); string(b) != got {
+			t.Errorf("AppendStripLinks(%q) = %q, want %q", s, b, got)
+		}
+
+		valid(t, "StripElements", cfw.StripElements(s, false, name), s)
+	})
+}
+
+func FuzzTimeDistance(f *testing.F) {
+	f.Add(int64(0), int64(90), true)
+	f.Add(int64(-1), int64(1<<40), false)
+	f.Fuzz(func(t *testing.T, from, to int64, seconds bool) {
+		if got := cfw.TimeDistance(time.Unix(from, 0), time.Unix(to, 0), seconds); got == "" {
+			t.Errorf("TimeDistance(%d, %d) is empty", from, to)
+		}
+	})
+}
+
+func FuzzTruncate(f *testing.F) {
+	f.Add("this is a test to see if this works or not.", "[more]", 20, uint8(0))
+	f.Add("The quick brown 🦊 jumps over the lazy 🐕", "💬", 21, uint8(1))
+	f.Add("The quick brown 🦊 jumps over the lazy 🐕", "[more]", 3, uint8(2))
+	f.Add(fuzzHTML, "", 12, uint8(8))
+	f.Fuzz(func(t *testing.T, s, replace string, n int, flags uint8) {
+		got := cfw.Truncate(s, replace, n)
+		valid(t, "Truncate", got, s, replace)
+
+		if n >= 0 && utf8.RuneCountInString(got) > n && utf8.RuneCountInString(s) > n {
+			t.Errorf("Truncate(%q, %q, %d) = %q, is longer than %d", s, replace, n, got, n)
+		}
+
+		if b := cfw.AppendTruncate(nil, []byte(s), replace, n); string(b) != got {
+			t.Errorf("AppendTruncate(%q) = %q, want %q", s, b, got)
+		}
+
+		opts := cfw.TruncateOptions{
+			Replace:   replace,
+			Unit:      cfw.Unit(flags & 1),
+			Boundary:  flags&2 != 0,
+			Exclusive: flags&4 != 0,
+			HTML:      flags&8 != 0,
+		}
+		got = cfw.TruncateWith(s, n, opts)
+		valid(t, "TruncateWith", got, s, replace)
+
+		if opts.Exclusive || opts.HTML || n < 0 {
+			return
+		}
+
+		size := utf8.RuneCountInString
+		if opts.Unit == cfw.Bytes {
+			size = func(s string) int { return len(s) }
+		}
+
+		if size(got) > n && size(s) > n {
+			t.Errorf("TruncateWith(%q, %q, %d, %+v) = %q, is longer than %d", s, replace, n, opts, got, n)
+		}
+	})
+}
+
+func FuzzWrap(f *testing.F) {
+	f.Add("The quick brown fox jumps over the lazy dog", 10, false)
+	f.Add(" été\tété\r\nabcdefghijkl", 3, true)
+	f.Fuzz(func(t *testing.T, s string, n int, strip bool) {
+		got := cfw.Wrap(s, n, strip)
+		valid(t, "Wrap", got, s)
+
+		if n < 1 || !utf8.ValidString(s) {
+			return
+		}
+
+		for _, line := range strings.Split(got, "\n") {
+			if c := utf8.RuneCountInString(strings.TrimSuffix(line, "\r")); c > n {
+				t.Errorf("Wrap(%q, %d) has a line of %d characters: %q", s, n, c, line)
+			}
+		}
+	})
+}
+
+func FuzzWordTruncate(f *testing.F) {
+	f.Add("CFWheels is a framework for ColdFusion", "", 4, false)
+	f.Add("The quick brown 🦊 jumps over the lazy 🐕", "💬", 4, false)
+	f.Add(fuzzHTML, "", 3, true)
+	f.Fuzz(func(t *testing.T, s, replace string, n int, html bool) {
+		got := cfw.WordTruncate(s, replace, n)
+		valid(t, "WordTruncate", got, s, replace)
+
+		if b := cfw.AppendWordTruncate(nil, []byte(s), replace, n); string(b) != got {
+			t.Errorf("AppendWordTruncate(%q) = %q, want %q", s, b, got)
+		}
+
+		valid(t, "WordTruncateWith", cfw.WordTruncateWith(s, n, cfw.TruncateOptions{Replace: replace, HTML: html}), s, replace)
+	})
+}
@@ -1,5 +1,8 @@
 module github.com/bengarrett/cfw
 
-go 1.17
+go 1.18
 
-require golang.org/x/text v0.21.0
+require (
+	golang.org/x/net v0.33.0
+	golang.org/x/text v0.21.0
+)
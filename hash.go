@@ -0,0 +1,107 @@
+package cfw
+
+import (
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"golang.org/x/text/encoding/ianaindex"
+)
+
+var (
+	// ErrAlgorithm is returned when the hash algorithm is not supported.
+	ErrAlgorithm = errors.New("unsupported hash algorithm")
+	// ErrCharset is returned when the character set is unknown.
+	ErrCharset = errors.New("unknown charset")
+)
+
+// hashes are the CFML hash algorithms, where CFMX_COMPAT is MD5,
+// and the other names are those of the Java MessageDigest algorithms used by Lucee.
+var hashes = map[string]func() hash.Hash{
+	"CFMX_COMPAT": md5.New,
+	"MD5":         md5.New,
+	"SHA":         sha1.New,
+	"SHA-1":       sha1.New,
+	"SHA-224":     sha256.New224,
+	"SHA-256":     sha256.New,
+	"SHA-384":     sha512.New384,
+	"SHA-512":     sha512.New,
+	"SHA-512/224": sha512.New512_224,
+	"SHA-512/256": sha512.New512_256,
+}
+
+// Hash returns the uppercase hexadecimal digest of s, the same as the CFML Hash function.
+//
+// The algorithm is one of CFMX_COMPAT, MD5, SHA, SHA-1, SHA-224, SHA-256, SHA-384, SHA-512, SHA-512/224
+// or SHA-512/256 in any case, where CFMX_COMPAT is MD5 and SHA is SHA-1, while an empty algorithm is MD5.
+// The other Java MessageDigest algorithms that Lucee accepts, MD2, SHA3-224, SHA3-256, SHA3-384 and SHA3-512,
+// are not supported and return an ErrAlgorithm error.
+// The charset is the IANA name of the character set used to convert s to bytes before it is hashed,
+// such as UTF-8, ISO-8859-1 or UTF-16, or the Java alias UTF8, while an empty charset is UTF-8.
+// As with Java, the UTF-16 charset is big-endian with a byte order mark,
+// and any character that is not in the charset is replaced with a ? character.
+// The iterations are the number of times the digest is hashed, where each iteration hashes the binary digest
+// of the previous iteration, and any value less than 1 is a single iteration.
+//
+// This function is a port of the CFML Hash function.
+func Hash(s, algorithm, charset string, iterations int) (string, error) {
+	if algorithm == "" {
+		algorithm = "MD5"
+	}
+
+	h, ok := hashes[strings.ToUpper(algorithm)]
+	if !ok {
+		return "", fmt.Errorf("hash %q: %w", algorithm, ErrAlgorithm)
+	}
+
+	b, err := charBytes(s, charset)
+	if err != nil {
+		return "", err
+	}
+
+	d := h()
+	for i := 0; i < iterations || i == 0; i++ {
+		d.Reset()
+		d.Write(b)
+		b = d.Sum(nil)
+	}
+
+	return strings.ToUpper(hex.EncodeToString(b)), nil
+}
+
+// charBytes returns s encoded in the charset, where an unsupported character is replaced with a ? character.
+// The UTF8 alias of Java is accepted, which is not an IANA name.
+func charBytes(s, charset string) ([]byte, error) {
+	if charset == "" || strings.EqualFold(charset, "utf-8") || strings.EqualFold(charset, "utf8") {
+		return []byte(s), nil
+	}
+
+	enc, err := ianaindex.IANA.Encoding(charset)
+	if err != nil || enc == nil {
+		return nil, fmt.Errorf("hash charset %q: %w", charset, ErrCharset)
+	}
+
+	if b, err := enc.NewEncoder().Bytes([]byte(s)); err == nil {
+		return b, nil
+	}
+
+	b := []byte{}
+	e := enc.NewEncoder()
+
+	for _, r := range s {
+		c, err := e.String(string(r))
+		if err != nil {
+			c = "?"
+		}
+
+		b = append(b, c...)
+	}
+
+	return b, nil
+}
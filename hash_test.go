@@ -0,0 +1,80 @@
+package cfw_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleHash() {
+	s, _ := cfw.Hash("hello", "SHA-256", "", 1)
+	fmt.Println(s)
+	// Output: 2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824
+}
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		s          string
+		algorithm  string
+		charset    string
+		iterations int
+	}
+
+	// The digests of a single iteration of UTF-8 text are the standard values of each algorithm.
+	//
+	// TODO: replace the iteration, UTF-16 and unsupported character vectors with values captured from Lucee.
+	// They were computed with Python's hashlib using the assumptions of this implementation,
+	// so they only check that it agrees with itself. It is not confirmed that n iterations are n digests
+	// rather than n+1, or that each iteration hashes the binary digest rather than its hexadecimal string.
+	// The text was encoded the same as Java's String.getBytes, which adds a big-endian byte order mark
+	// for UTF-16 and replaces an unmappable character with a ? character.
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{"default", args{"hello", "", "", 0}, "5D41402ABC4B2A76B9719D911017C592", nil},
+		{"empty", args{"", "MD5", "", 1}, "D41D8CD98F00B204E9800998ECF8427E", nil},
+		{"cfmx_compat", args{"hello", "CFMX_COMPAT", "", 1}, "5D41402ABC4B2A76B9719D911017C592", nil},
+		{"lowercase", args{"hello", "md5", "utf-8", 1}, "5D41402ABC4B2A76B9719D911017C592", nil},
+		{"sha", args{"hello", "SHA", "", 1}, "AAF4C61DDCC5E8A2DABEDE0F3B482CD9AEA9434D", nil},
+		{"sha-1", args{"hello", "SHA-1", "", 1}, "AAF4C61DDCC5E8A2DABEDE0F3B482CD9AEA9434D", nil},
+		{"sha-224", args{"hello", "SHA-224", "", 1}, "EA09AE9CC6768C50FCEE903ED054556E5BFC8347907F12598AA24193", nil},
+		{"sha-256", args{"hello", "SHA-256", "", 1}, "2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824", nil},
+		{"sha-384", args{"hello", "SHA-384", "", 1}, "59E1748777448C69DE6B800D7A33BBFB9FF1B463E44354C3553BCDB9C666FA90125A3C79F90397BDF5F6A13DE828684F", nil},
+		{"sha-512", args{"hello", "SHA-512", "", 1}, "9B71D224BD62F3785D96D46AD3EA3D73319BFBC2890CAADAE2DFF72519673CA72323C3D99BA5C11D7C7ACC6E14B8C5DA0C4663475C2E5C3ADEF46F73BCDEC043", nil},
+		{"sha-512/224", args{"hello", "sha-512/224", "", 1}, "FE8509ED1FB7DCEFC27E6AC1A80EDDBEC4CB3D2C6FE565244374061C", nil},
+		{"sha-512/256", args{"hello", "SHA-512/256", "", 1}, "E30D87CFA2A75DB545EAC4D61BAF970366A8357C7F72FA95B52D0ACCB698F13A", nil},
+		{"two iterations", args{"hello", "MD5", "", 2}, "62109206880D38A4010A98E11243924A", nil},
+		{"1000 iterations", args{"hello", "MD5", "", 1000}, "089BF95941670FE812805926953B37BE", nil},
+		{"negative iterations", args{"hello", "MD5", "", -1}, "5D41402ABC4B2A76B9719D911017C592", nil},
+		{"utf-8", args{"café", "MD5", "UTF-8", 1}, "07117FE4A1EBD544965DC19573183DA2", nil},
+		{"utf8 alias", args{"café", "MD5", "utf8", 1}, "07117FE4A1EBD544965DC19573183DA2", nil},
+		{"sha-512 iterations", args{"hello", "SHA-512", "", 3}, "6D9F8ED2218B64D92F5E09788F29C56E3A7C3FF7BD5B2677B452AC1E3E5F9CBACF0F67CB9E27DA424B4D3C325C27E5A6F8B563AE26972E47F0BFE4012F0474FE", nil},
+		{"latin-1", args{"café", "MD5", "ISO-8859-1", 1}, "961F50F6282239D09E48F812C1CA7276", nil},
+		{"utf-16", args{"café", "MD5", "UTF-16", 1}, "109B766EAAB1A59AA8AFD73E6CD42700", nil},
+		{"unsupported character", args{"a€", "MD5", "ISO-8859-1", 1}, "771D1F0F5E79495265522D3392FFBADB", nil},
+		{"unsupported characters", args{"日本", "MD5", "latin1", 1}, "EA03FCB8C47822BCE772CF6C07D0EBBB", nil},
+		{"algorithm", args{"hello", "SHA-3", "", 1}, "", cfw.ErrAlgorithm},
+		{"sha3", args{"hello", "SHA3-256", "", 1}, "", cfw.ErrAlgorithm},
+		{"charset", args{"hello", "MD5", "klingon", 1}, "", cfw.ErrCharset},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := cfw.Hash(tt.args.s, tt.args.algorithm, tt.args.charset, tt.args.iterations)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Hash() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Hash() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,761 @@
+package cfw
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cfmlDate is the layout of a date serialized by the CFML SerializeJSON function.
+const cfmlDate = "January, 02 2006 15:04:05"
+
+// ErrJSON is returned when a value cannot be serialized as CFML JSON.
+var ErrJSON = errors.New("unsupported json value")
+
+// Query is a CFML query object, which is a recordset of rows with named columns.
+type Query struct {
+	Columns []string        // Columns are the column names.
+	Data    [][]interface{} // Data are the rows, each with a value for every column.
+}
+
+// JSONOptions changes the JSON returned by SerializeJSONWith.
+type JSONOptions struct {
+	// QueryByColumns serializes a Query as an object with a ROWCOUNT and the DATA of each column,
+	// instead of an array of rows, the same as the serializeQueryByColumns argument of SerializeJSON.
+	QueryByColumns bool
+	// PreserveCase keeps the case of the map keys and struct field names, instead of using uppercase.
+	PreserveCase bool
+}
+
+// SerializeJSON returns v as JSON with the quirks of the CFML SerializeJSON function,
+// so the JSON can be read by clients that expect the CFML output.
+//
+//   - The keys of maps and the names of struct fields are uppercase, where a struct field uses its json tag name.
+//   - All numbers are floating-point in the Java format, such as 1.0, 1.5 or 1.0E7.
+//   - A / character in a string is escaped as \/.
+//   - A time.Time is a string in the format "January, 05 2026 15:04:05".
+//   - A Query is an object with the COLUMNS names and the DATA of each row.
+//
+// The map keys are sorted, and a byte slice is a Base64 string.
+// A value with a MarshalJSON or MarshalText method, such as a UUID, is written using the method,
+// and the fields of an embedded struct are promoted, the same as encoding/json.
+// An error is returned if v contains a value that cannot be serialized, such as a channel, a function or NaN.
+func SerializeJSON(v interface{}) (string, error) {
+	return SerializeJSONWith(v, JSONOptions{})
+}
+
+// SerializeJSONWith is the same as SerializeJSON, except the JSON can be configured using opts.
+func SerializeJSONWith(v interface{}, opts JSONOptions) (string, error) {
+	var b bytes.Buffer
+	if err := opts.encode(&b, reflect.ValueOf(v)); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// DeserializeJSON parses the JSON created by SerializeJSON or the CFML SerializeJSON function and stores it in v.
+//
+// When v is a pointer to an empty interface, the objects are maps, the numbers are float64,
+// and any query objects in either format are a Query.
+// Otherwise, the JSON is stored using encoding/json, where the object keys match the struct fields
+// ignoring case, a query object is an array of objects that each have a key for every column,
+// and an integral floating-point number such as 1.0 can be stored in an integer.
+func DeserializeJSON(s string, v interface{}) error {
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+
+	var raw interface{}
+	if err := d.Decode(&raw); err != nil {
+		return fmt.Errorf("deserialize json: %w", err)
+	}
+
+	if p, ok := v.(*interface{}); ok {
+		*p = generic(raw)
+
+		return nil
+	}
+
+	b, err := json.Marshal(normalize(raw))
+	if err != nil {
+		return fmt.Errorf("deserialize json: %w", err)
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("deserialize json: %w", err)
+	}
+
+	return nil
+}
+
+func (o JSONOptions) encode(b *bytes.Buffer, v reflect.Value) error {
+	if !v.IsValid() {
+		b.WriteString("null")
+
+		return nil
+	}
+
+	if !v.CanInterface() {
+		return o.kind(b, v)
+	}
+
+	switch x := v.Interface().(type) {
+	case time.Time:
+		writeString(b, x.Format(cfmlDate))
+
+		return nil
+	case Query:
+		return o.query(b, x)
+	case *Query:
+		if x == nil {
+			b.WriteString("null")
+
+			return nil
+		}
+
+		return o.query(b, *x)
+	case json.Number:
+		f, err := x.Float64()
+		if err != nil {
+			return fmt.Errorf("serialize json number %q: %w", x, ErrJSON)
+		}
+
+		return writeNumber(b, f)
+	}
+
+	if ok, err := o.marshal(b, v); ok {
+		return err
+	}
+
+	return o.kind(b, v)
+}
+
+// kind writes v using the JSON of its kind.
+func (o JSONOptions) kind(b *bytes.Buffer, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("null")
+
+			return nil
+		}
+
+		return o.encode(b, v.Elem())
+	case reflect.Bool:
+		b.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return writeNumber(b, float64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return writeNumber(b, float64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return writeNumber(b, v.Float())
+	case reflect.String:
+		writeString(b, v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			b.WriteString("null")
+
+			return nil
+		}
+
+		if v.Type().Elem().Kind() == reflect.Uint8 && v.Kind() == reflect.Slice {
+			writeString(b, base64.StdEncoding.EncodeToString(v.Bytes()))
+
+			return nil
+		}
+
+		return o.array(b, v)
+	case reflect.Map:
+		return o.object(b, v)
+	case reflect.Struct:
+		return o.structure(b, v)
+	default:
+		return fmt.Errorf("serialize json %s: %w", v.Type(), ErrJSON)
+	}
+
+	return nil
+}
+
+// marshal writes v using its MarshalJSON or MarshalText method, the same as encoding/json,
+// and reports whether v has either method.
+// The JSON of a MarshalJSON method is written again with the CFML quirks, such as the uppercase keys.
+func (o JSONOptions) marshal(b *bytes.Buffer, v reflect.Value) (bool, error) {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return false, nil
+	}
+
+	switch x := v.Interface().(type) {
+	case json.Marshaler:
+		j, err := x.MarshalJSON()
+		if err != nil {
+			return true, fmt.Errorf("serialize json %s: %w", v.Type(), err)
+		}
+
+		d := json.NewDecoder(bytes.NewReader(j))
+		d.UseNumber()
+
+		var raw interface{}
+		if err := d.Decode(&raw); err != nil {
+			return true, fmt.Errorf("serialize json %s: %w", v.Type(), err)
+		}
+
+		return true, o.encode(b, reflect.ValueOf(raw))
+	case encoding.TextMarshaler:
+		text, err := x.MarshalText()
+		if err != nil {
+			return true, fmt.Errorf("serialize json %s: %w", v.Type(), err)
+		}
+
+		writeString(b, string(text))
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (o JSONOptions) array(b *bytes.Buffer, v reflect.Value) error {
+	b.WriteByte('[')
+
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		if err := o.encode(b, v.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	b.WriteByte(']')
+
+	return nil
+}
+
+func (o JSONOptions) object(b *bytes.Buffer, v reflect.Value) error {
+	if v.IsNil() {
+		b.WriteString("null")
+
+		return nil
+	}
+
+	if v.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("serialize json %s: %w", v.Type(), ErrJSON)
+	}
+
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	b.WriteByte('{')
+
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		o.key(b, k.String())
+
+		if err := o.encode(b, v.MapIndex(k)); err != nil {
+			return err
+		}
+	}
+
+	b.WriteByte('}')
+
+	return nil
+}
+
+func (o JSONOptions) structure(b *bytes.Buffer, v reflect.Value) error {
+	b.WriteByte('{')
+
+	for i, f := range fields(v) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		o.key(b, f.name)
+
+		if err := o.encode(b, f.value); err != nil {
+			return err
+		}
+	}
+
+	b.WriteByte('}')
+
+	return nil
+}
+
+// field is a named, exported field of a struct value.
+type field struct {
+	name   string
+	value  reflect.Value
+	index  []int // index is the sequence of field indexes, which includes those of any embedded structs.
+	tagged bool  // tagged is true when the name is from a json tag.
+	omit   bool  // omit is true when the json tag has the omitempty option.
+}
+
+// fields returns the exported fields of the struct v, using the names and the omitempty option of the json tags.
+// A field with the json tag "-" is skipped.
+// The fields of an embedded struct without a json tag name are promoted, the same as encoding/json,
+// where the shallowest field of a name is used, unless there are more than one without a single tagged field.
+func fields(v reflect.Value) []field {
+	all := structFields(v, nil, map[reflect.Type]bool{})
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].name != all[j].name {
+			return all[i].name < all[j].name
+		}
+
+		if len(all[i].index) != len(all[j].index) {
+			return len(all[i].index) < len(all[j].index)
+		}
+
+		return all[i].tagged && !all[j].tagged
+	})
+
+	fs := []field{}
+
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].name == all[i].name {
+			j++
+		}
+
+		if f, ok := dominant(all[i:j]); ok && !(f.omit && f.value.IsZero()) {
+			fs = append(fs, f)
+		}
+
+		i = j
+	}
+
+	sort.Slice(fs, func(i, j int) bool {
+		x, y := fs[i].index, fs[j].index
+		for k := 0; k < len(x) && k < len(y); k++ {
+			if x[k] != y[k] {
+				return x[k] < y[k]
+			}
+		}
+
+		return len(x) < len(y)
+	})
+
+	return fs
+}
+
+// structFields returns all the fields of the struct v, including the fields of the embedded structs,
+// where index is the sequence of field indexes to v and seen are the struct types that embed v.
+func structFields(v reflect.Value, index []int, seen map[reflect.Type]bool) []field {
+	t := v.Type()
+	seen[t] = true
+
+	defer delete(seen, t)
+
+	fs := []field{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag, _ := f.Tag.Lookup("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		idx := append(index[:len(index):len(index)], i)
+
+		if f.Anonymous && name == "" {
+			ft, fv := f.Type, v.Field(i)
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				if fv.Kind() == reflect.Ptr {
+					if fv.IsNil() {
+						continue
+					}
+
+					fv = fv.Elem()
+				}
+
+				if !seen[ft] {
+					fs = append(fs, structFields(fv, idx, seen)...)
+				}
+
+				continue
+			}
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		fs = append(fs, field{
+			name:   f.Name,
+			value:  v.Field(i),
+			index:  idx,
+			tagged: name != "",
+			omit:   strings.Contains(","+opts+",", ",omitempty,"),
+		})
+
+		if name != "" {
+			fs[len(fs)-1].name = name
+		}
+	}
+
+	return fs
+}
+
+// dominant returns the field used for fs, which are the fields of a name sorted by depth and then the tagged fields.
+// It returns false if there are more than one shallowest field and the first of them isn't the only tagged field.
+func dominant(fs []field) (field, bool) {
+	if len(fs) > 1 && len(fs[0].index) == len(fs[1].index) && fs[0].tagged == fs[1].tagged {
+		return field{}, false
+	}
+
+	return fs[0], true
+}
+
+func (o JSONOptions) query(b *bytes.Buffer, q Query) error {
+	cols := make([]string, len(q.Columns))
+	for i, c := range q.Columns {
+		cols[i] = o.name(c)
+	}
+
+	if !o.QueryByColumns {
+		b.WriteString(`{"COLUMNS":`)
+
+		if err := o.encode(b, reflect.ValueOf(cols)); err != nil {
+			return err
+		}
+
+		b.WriteString(`,"DATA":`)
+
+		if q.Data == nil {
+			b.WriteString("[]")
+		} else if err := o.encode(b, reflect.ValueOf(q.Data)); err != nil {
+			return err
+		}
+
+		b.WriteByte('}')
+
+		return nil
+	}
+
+	b.WriteString(`{"ROWCOUNT":`)
+	b.WriteString(strconv.Itoa(len(q.Data)))
+	b.WriteString(`,"COLUMNS":`)
+
+	if err := o.encode(b, reflect.ValueOf(cols)); err != nil {
+		return err
+	}
+
+	b.WriteString(`,"DATA":{`)
+
+	for i, c := range cols {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		writeString(b, c)
+		b.WriteString(":[")
+
+		for r, row := range q.Data {
+			if r > 0 {
+				b.WriteByte(',')
+			}
+
+			var cell interface{}
+			if i < len(row) {
+				cell = row[i]
+			}
+
+			if err := o.encode(b, reflect.ValueOf(cell)); err != nil {
+				return err
+			}
+		}
+
+		b.WriteByte(']')
+	}
+
+	b.WriteString("}}")
+
+	return nil
+}
+
+func (o JSONOptions) name(s string) string {
+	if o.PreserveCase {
+		return s
+	}
+
+	return strings.ToUpper(s)
+}
+
+func (o JSONOptions) key(b *bytes.Buffer, s string) {
+	writeString(b, o.name(s))
+	b.WriteByte(':')
+}
+
+// writeNumber writes f in the format of the Java Double.toString method,
+// which uses scientific notation for values less than 0.001 or greater than or equal to 10,000,000.
+func writeNumber(b *bytes.Buffer, f float64) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("serialize json %v: %w", f, ErrJSON)
+	}
+
+	const small, large = 1e-3, 1e7
+
+	if abs := math.Abs(f); abs != 0 && (abs < small || abs >= large) {
+		s := strconv.FormatFloat(f, 'E', -1, 64)
+		mantissa, exp, _ := strings.Cut(s, "E")
+
+		if !strings.Contains(mantissa, ".") {
+			mantissa += ".0"
+		}
+
+		n, _ := strconv.Atoi(exp)
+		b.WriteString(mantissa + "E" + strconv.Itoa(n))
+
+		return nil
+	}
+
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if math.Signbit(f) && f == 0 {
+		s = "-0"
+	}
+
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+
+	b.WriteString(s)
+
+	return nil
+}
+
+// writeString writes s as a JSON string, where the / character is escaped and the HTML characters are not.
+func writeString(b *bytes.Buffer, s string) {
+	const hexDigits = "0123456789abcdef"
+
+	b.WriteByte('"')
+
+	for _, r := range s {
+		switch r {
+		case '"', '\\', '/':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < ' ' {
+				b.WriteString(`\u00`)
+				b.WriteByte(hexDigits[r>>4])
+				b.WriteByte(hexDigits[r&0xf])
+
+				continue
+			}
+
+			b.WriteRune(r)
+		}
+	}
+
+	b.WriteByte('"')
+}
+
+// generic returns the decoded JSON with float64 numbers and Query objects.
+func generic(v interface{}) interface{} {
+	switch x := v.(type) {
+	case json.Number:
+		f, _ := x.Float64()
+
+		return f
+	case []interface{}:
+		for i := range x {
+			x[i] = generic(x[i])
+		}
+
+		return x
+	case map[string]interface{}:
+		if q, ok := toQuery(x); ok {
+			for _, row := range q.Data {
+				for i := range row {
+					row[i] = generic(row[i])
+				}
+			}
+
+			return q
+		}
+
+		for k := range x {
+			x[k] = generic(x[k])
+		}
+
+		return x
+	default:
+		return v
+	}
+}
+
+// normalize returns the decoded JSON with integral numbers and query objects as arrays of row objects.
+func normalize(v interface{}) interface{} {
+	switch x := v.(type) {
+	case json.Number:
+		const maxInt = 1 << 53
+
+		if f, err := x.Float64(); err == nil && f == math.Trunc(f) && math.Abs(f) < maxInt {
+			return json.Number(strconv.FormatFloat(f, 'f', -1, 64))
+		}
+
+		return x
+	case []interface{}:
+		for i := range x {
+			x[i] = normalize(x[i])
+		}
+
+		return x
+	case map[string]interface{}:
+		if q, ok := toQuery(x); ok {
+			rows := make([]interface{}, 0, len(q.Data))
+
+			for _, row := range q.Data {
+				m := make(map[string]interface{}, len(q.Columns))
+				for i, c := range q.Columns {
+					if i < len(row) {
+						m[c] = normalize(row[i])
+					}
+				}
+
+				rows = append(rows, m)
+			}
+
+			return rows
+		}
+
+		for k := range x {
+			x[k] = normalize(x[k])
+		}
+
+		return x
+	default:
+		return v
+	}
+}
+
+// toQuery returns the Query of a decoded query object, in either the row or column format.
+func toQuery(m map[string]interface{}) (Query, bool) {
+	if len(m) != 2 && len(m) != 3 {
+		return Query{}, false
+	}
+
+	var cols, data, count interface{}
+
+	for k, v := range m {
+		switch strings.ToUpper(k) {
+		case "COLUMNS":
+			cols = v
+		case "DATA":
+			data = v
+		case "ROWCOUNT":
+			count = v
+		default:
+			return Query{}, false
+		}
+	}
+
+	names, ok := cols.([]interface{})
+	if !ok {
+		return Query{}, false
+	}
+
+	q := Query{Columns: make([]string, len(names))}
+
+	for i, n := range names {
+		s, ok := n.(string)
+		if !ok {
+			return Query{}, false
+		}
+
+		q.Columns[i] = s
+	}
+
+	switch d := data.(type) {
+	case []interface{}:
+		if count != nil {
+			return Query{}, false
+		}
+
+		for _, r := range d {
+			row, ok := r.([]interface{})
+			if !ok {
+				return Query{}, false
+			}
+
+			q.Data = append(q.Data, row)
+		}
+
+		return q, true
+	case map[string]interface{}:
+		return byColumns(q, d, count)
+	default:
+		return Query{}, false
+	}
+}
+
+// byColumns returns the Query of the serializeQueryByColumns format, where data contains the values of each column.
+func byColumns(q Query, data map[string]interface{}, count interface{}) (Query, bool) {
+	n, ok := count.(json.Number)
+	if !ok {
+		return Query{}, false
+	}
+
+	rows, err := n.Float64()
+	if err != nil {
+		return Query{}, false
+	}
+
+	cols := make([][]interface{}, len(q.Columns))
+	for i, c := range q.Columns {
+		values, ok := data[c].([]interface{})
+		if !ok || (i > 0 && len(values) != len(cols[0])) {
+			return Query{}, false
+		}
+
+		cols[i] = values
+	}
+	// the row count is checked against the columns before any rows are made,
+	// so a query without columns has no rows
+	size := 0
+	if len(cols) > 0 {
+		size = len(cols[0])
+	}
+
+	if rows != float64(size) {
+		return Query{}, false
+	}
+
+	q.Data = make([][]interface{}, size)
+	for r := range q.Data {
+		q.Data[r] = make([]interface{}, len(q.Columns))
+		for i := range cols {
+			q.Data[r][i] = cols[i][r]
+		}
+	}
+
+	return q, true
+}
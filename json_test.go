@@ -0,0 +1,266 @@
+package cfw_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleSerializeJSON() {
+	s, _ := cfw.SerializeJSON(map[string]interface{}{"id": 1, "url": "http://example.com"})
+	fmt.Println(s)
+	// Output: {"ID":1.0,"URL":"http:\/\/example.com"}
+}
+
+func ExampleSerializeJSONWith() {
+	q := cfw.Query{
+		Columns: []string{"id", "name"},
+		Data:    [][]interface{}{{1, "Ada"}, {2, "Grace"}},
+	}
+	s, _ := cfw.SerializeJSON(q)
+	fmt.Println(s)
+	s, _ = cfw.SerializeJSONWith(q, cfw.JSONOptions{QueryByColumns: true})
+	fmt.Println(s)
+	// Output: {"COLUMNS":["ID","NAME"],"DATA":[[1.0,"Ada"],[2.0,"Grace"]]}
+	// {"ROWCOUNT":2,"COLUMNS":["ID","NAME"],"DATA":{"ID":[1.0,2.0],"NAME":["Ada","Grace"]}}
+}
+
+func ExampleDeserializeJSON() {
+	var users []struct {
+		ID   int
+		Name string
+	}
+	_ = cfw.DeserializeJSON(`{"COLUMNS":["ID","NAME"],"DATA":[[1.0,"Ada"],[2.0,"Grace"]]}`, &users)
+	fmt.Println(users)
+	// Output: [{1 Ada} {2 Grace}]
+}
+
+func TestSerializeJSON(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID      int
+		Name    string `json:"fullName"`
+		Email   string `json:",omitempty"`
+		Secret  string `json:"-"`
+		private string
+	}
+
+	type Inner struct {
+		ID   int
+		Role string
+	}
+
+	type account struct {
+		Inner
+		ID   string
+		UUID cfw.UUID
+	}
+
+	date := time.Date(2026, time.January, 5, 15, 4, 5, 0, time.UTC)
+	id := 7
+	uuid, _ := cfw.ParseUUID("7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F")
+
+	tests := []struct {
+		name    string
+		v       interface{}
+		want    string
+		wantErr error
+	}{
+		{"nil", nil, "null", nil},
+		{"true", true, "true", nil},
+		{"int", 1, "1.0", nil},
+		{"negative", -42, "-42.0", nil},
+		{"uint", uint8(3), "3.0", nil},
+		{"float", 1.5, "1.5", nil},
+		{"zero", 0.0, "0.0", nil},
+		{"small", 0.001, "0.001", nil},
+		{"smaller", 0.0001, "1.0E-4", nil},
+		{"large", 9999999, "9999999.0", nil},
+		{"larger", 10000000, "1.0E7", nil},
+		{"exponent", 1.25e20, "1.25E20", nil},
+		{"string", `a "b" / c\d`, `"a \"b\" \/ c\\d"`, nil},
+		{"html", "<b>&</b>", `"<b>&<\/b>"`, nil},
+		{"control", "a\tb\nc\x01", `"a\tb\nc\u0001"`, nil},
+		{"unicode", "café", `"café"`, nil},
+		{"date", date, `"January, 05 2026 15:04:05"`, nil},
+		{"bytes", []byte("hi"), `"aGk="`, nil},
+		{"nil slice", []int(nil), "null", nil},
+		{"array", [2]int{1, 2}, "[1.0,2.0]", nil},
+		{"slice", []interface{}{1, "a", nil}, `[1.0,"a",null]`, nil},
+		{"map", map[string]int{"b": 2, "a": 1}, `{"A":1.0,"B":2.0}`, nil},
+		{"pointer", &id, "7.0", nil},
+		{"struct", user{ID: 1, Name: "Ada", Secret: "x"}, `{"ID":1.0,"FULLNAME":"Ada"}`, nil},
+		{"omitempty", user{Email: "a@b"}, `{"ID":0.0,"FULLNAME":"","EMAIL":"a@b"}`, nil},
+		{"nil query", (*cfw.Query)(nil), "null", nil},
+		{"uuid", uuid, `"7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F"`, nil},
+		{"embedded", account{Inner{1, "admin"}, "a", uuid},
+			`{"ROLE":"admin","ID":"a","UUID":"7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F"}`, nil},
+		{"marshaler", json.RawMessage(`{"a":[1,"b"]}`), `{"A":[1.0,"b"]}`, nil},
+		{"nan", math.NaN(), "", cfw.ErrJSON},
+		{"func", func() {}, "", cfw.ErrJSON},
+		{"int key", map[int]int{1: 1}, "", cfw.ErrJSON},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := cfw.SerializeJSON(tt.v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SerializeJSON() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SerializeJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSerializeJSONWith(t *testing.T) {
+	t.Parallel()
+
+	q := cfw.Query{Columns: []string{"id", "name"}, Data: [][]interface{}{{1, "Ada"}, {2}}}
+
+	tests := []struct {
+		name string
+		v    interface{}
+		opts cfw.JSONOptions
+		want string
+	}{
+		{"rows", &q, cfw.JSONOptions{}, `{"COLUMNS":["ID","NAME"],"DATA":[[1.0,"Ada"],[2.0]]}`},
+		{
+			"columns", q, cfw.JSONOptions{QueryByColumns: true},
+			`{"ROWCOUNT":2,"COLUMNS":["ID","NAME"],"DATA":{"ID":[1.0,2.0],"NAME":["Ada",null]}}`,
+		},
+		{"empty", cfw.Query{}, cfw.JSONOptions{QueryByColumns: true}, `{"ROWCOUNT":0,"COLUMNS":[],"DATA":{}}`},
+		{"nil data", cfw.Query{Columns: []string{"ID"}}, cfw.JSONOptions{}, `{"COLUMNS":["ID"],"DATA":[]}`},
+		{"preserve", map[string]interface{}{"q": q}, cfw.JSONOptions{PreserveCase: true},
+			`{"q":{"COLUMNS":["id","name"],"DATA":[[1.0,"Ada"],[2.0]]}}`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := cfw.SerializeJSONWith(tt.v, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("SerializeJSONWith() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeserializeJSON(t *testing.T) {
+	t.Parallel()
+
+	want := cfw.Query{Columns: []string{"ID", "NAME"}, Data: [][]interface{}{{1.0, "Ada"}, {2.0, "Grace"}}}
+
+	tests := []struct {
+		name    string
+		s       string
+		want    interface{}
+		wantErr bool
+	}{
+		{"number", "1.0", 1.0, false},
+		{"string", `"a\/b"`, "a/b", false},
+		{"object", `{"ID":1.0,"TAGS":["a"]}`, map[string]interface{}{"ID": 1.0, "TAGS": []interface{}{"a"}}, false},
+		{"rows", `{"COLUMNS":["ID","NAME"],"DATA":[[1.0,"Ada"],[2.0,"Grace"]]}`, want, false},
+		{"columns", `{"ROWCOUNT":2.0,"COLUMNS":["ID","NAME"],"DATA":{"ID":[1.0,2.0],"NAME":["Ada","Grace"]}}`, want, false},
+		{"integer rowcount", `{"ROWCOUNT":2,"COLUMNS":["ID","NAME"],"DATA":{"ID":[1.0,2.0],"NAME":["Ada","Grace"]}}`, want, false},
+		{
+			"rowcount mismatch", `{"ROWCOUNT":3,"COLUMNS":["ID"],"DATA":{"ID":[1.0]}}`,
+			map[string]interface{}{"ROWCOUNT": 3.0, "COLUMNS": []interface{}{"ID"}, "DATA": map[string]interface{}{"ID": []interface{}{1.0}}},
+			false,
+		},
+		{
+			"rowcount without columns", `{"ROWCOUNT":1e19,"COLUMNS":[],"DATA":{}}`,
+			map[string]interface{}{"ROWCOUNT": 1e19, "COLUMNS": []interface{}{}, "DATA": map[string]interface{}{}},
+			false,
+		},
+		{"nested", `[{"columns":["ID","NAME"],"data":[[1,"Ada"],[2,"Grace"]]}]`, []interface{}{want}, false},
+		{
+			"not query", `{"COLUMNS":["ID"],"DATA":{"ID":[1.0]}}`,
+			map[string]interface{}{"COLUMNS": []interface{}{"ID"}, "DATA": map[string]interface{}{"ID": []interface{}{1.0}}},
+			false,
+		},
+		{"invalid", `{"ID":`, nil, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got interface{}
+			err := cfw.DeserializeJSON(tt.s, &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeserializeJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeserializeJSON() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeserializeJSONStruct(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID    int64
+		Name  string
+		Score float64
+	}
+
+	type reply struct {
+		Total int
+		Users []user
+	}
+
+	const s = `{"TOTAL":2.0,"USERS":{"ROWCOUNT":2.0,"COLUMNS":["ID","NAME","SCORE"],` +
+		`"DATA":{"ID":[1.0,2.0],"NAME":["Ada","Grace"],"SCORE":[1.5,1.0E7]}}}`
+
+	want := reply{Total: 2, Users: []user{{1, "Ada", 1.5}, {2, "Grace", 1e7}}}
+
+	var got reply
+	if err := cfw.DeserializeJSON(s, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DeserializeJSON() = %+v, want %+v", got, want)
+	}
+
+	var n int
+	if err := cfw.DeserializeJSON("1.5", &n); err == nil {
+		t.Errorf("DeserializeJSON() of a fraction to an int = %d, want an error", n)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	q := cfw.Query{Columns: []string{"A", "B"}, Data: [][]interface{}{{1.0, "x"}, {nil, true}}}
+
+	for _, opts := range []cfw.JSONOptions{{}, {QueryByColumns: true}} {
+		s, err := cfw.SerializeJSONWith(q, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got interface{}
+		if err := cfw.DeserializeJSON(s, &got); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(got, q) {
+			t.Errorf("round trip %+v = %#v, want %#v", opts, got, q)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package cfw
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const listDelimiter = ","
+
+// ListOptions changes how the List functions split a CFML delimited list into elements.
+// The zero value splits a list on commas and skips any empty elements, the same as CFML.
+type ListOptions struct {
+	// Delimiters are the characters that separate the list elements, which defaults to a comma.
+	// Any one of the characters is a delimiter, unless MultiCharDelimiter is used.
+	Delimiters string
+	// IncludeEmpty keeps the empty elements between consecutive delimiters,
+	// and at the start or end of the list, instead of skipping them.
+	IncludeEmpty bool
+	// MultiCharDelimiter uses the whole of Delimiters as a single delimiter, such as "::" or ", ".
+	MultiCharDelimiter bool
+}
+
+// SortType is the comparison used by ListSort.
+type SortType int
+
+const (
+	SortText       SortType = iota // SortText sorts the elements in case-sensitive, Unicode code point order.
+	SortTextNoCase                 // SortTextNoCase sorts the elements ignoring case.
+	SortNumeric                    // SortNumeric sorts the elements as numbers, with any other elements last.
+)
+
+// span is the start and end byte offsets of a list element.
+type span struct {
+	start, end int
+}
+
+func (o ListOptions) delimiters() string {
+	if o.Delimiters == "" {
+		return listDelimiter
+	}
+
+	return o.Delimiters
+}
+
+// delimiter returns the delimiter used to add or join elements, which is the first of the delimiters.
+func (o ListOptions) delimiter() string {
+	d := o.delimiters()
+	if o.MultiCharDelimiter {
+		return d
+	}
+
+	_, size := utf8.DecodeRuneInString(d)
+
+	return d[:size]
+}
+
+// delim returns the byte length of the delimiter at the start of s, or 0 if s does not start with a delimiter.
+func (o ListOptions) delim(s string) int {
+	d := o.delimiters()
+	if o.MultiCharDelimiter {
+		if strings.HasPrefix(s, d) {
+			return len(d)
+		}
+
+		return 0
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError && size <= 1 {
+		return 0
+	}
+
+	if strings.ContainsRune(d, r) {
+		return size
+	}
+
+	return 0
+}
+
+// spans returns the offsets of the elements of the list.
+func (o ListOptions) spans(list string) []span {
+	if list == "" {
+		return nil
+	}
+
+	spans := []span{}
+	start := 0
+
+	for i := 0; i < len(list); {
+		n := o.delim(list[i:])
+		if n == 0 {
+			_, size := utf8.DecodeRuneInString(list[i:])
+			i += size
+
+			continue
+		}
+
+		if o.IncludeEmpty || i > start {
+			spans = append(spans, span{start, i})
+		}
+
+		i += n
+		start = i
+	}
+
+	if o.IncludeEmpty || len(list) > start {
+		spans = append(spans, span{start, len(list)})
+	}
+
+	return spans
+}
+
+// elements returns the elements of the list.
+func (o ListOptions) elements(list string) []string {
+	spans := o.spans(list)
+	elems := make([]string, 0, len(spans))
+
+	for _, s := range spans {
+		elems = append(elems, list[s.start:s.end])
+	}
+
+	return elems
+}
+
+// ListLen returns the number of elements in the CFML delimited list.
+func ListLen(list string, opts ListOptions) int {
+	return len(opts.spans(list))
+}
+
+// ListFirst returns the first element of the CFML delimited list, or an empty string if the list is empty.
+func ListFirst(list string, opts ListOptions) string {
+	return ListGetAt(list, 1, opts)
+}
+
+// ListLast returns the last element of the CFML delimited list, or an empty string if the list is empty.
+func ListLast(list string, opts ListOptions) string {
+	return ListGetAt(list, ListLen(list, opts), opts)
+}
+
+// ListRest returns the CFML delimited list without its first element and the delimiters that follow it.
+// An empty string is returned if the list has one or no elements.
+func ListRest(list string, opts ListOptions) string {
+	spans := opts.spans(list)
+	if len(spans) < 2 {
+		return ""
+	}
+
+	if opts.IncludeEmpty {
+		return list[spans[0].end+opts.delim(list[spans[0].end:]):]
+	}
+
+	return list[spans[1].start:]
+}
+
+// ListGetAt returns the element at position n of the CFML delimited list, where the first element is 1.
+// An empty string is returned if n is out of range.
+func ListGetAt(list string, n int, opts ListOptions) string {
+	spans := opts.spans(list)
+	if n < 1 || n > len(spans) {
+		return ""
+	}
+
+	s := spans[n-1]
+
+	return list[s.start:s.end]
+}
+
+// ListSetAt replaces the element at position n of the CFML delimited list with value,
+// where the first element is 1. The delimiters of the list are kept as is.
+// The list is returned unchanged if n is out of range.
+func ListSetAt(list string, n int, value string, opts ListOptions) string {
+	spans := opts.spans(list)
+	if n < 1 || n > len(spans) {
+		return list
+	}
+
+	s := spans[n-1]
+
+	return list[:s.start] + value + list[s.end:]
+}
+
+// ListDeleteAt removes the element at position n of the CFML delimited list, where the first element is 1.
+// The delimiters before the element are also removed, or for the first element, the delimiters after it.
+// The list is returned unchanged if n is out of range.
+func ListDeleteAt(list string, n int, opts ListOptions) string {
+	spans := opts.spans(list)
+	if n < 1 || n > len(spans) {
+		return list
+	}
+
+	s := spans[n-1]
+
+	switch {
+	case len(spans) == 1:
+		return list[:s.start] + list[s.end:]
+	case n == 1 && opts.IncludeEmpty:
+		return list[:s.start] + list[s.end+opts.delim(list[s.end:]):]
+	case n == 1:
+		return list[:s.start] + list[spans[1].start:]
+	default:
+		return list[:spans[n-2].end] + list[s.end:]
+	}
+}
+
+// ListFind returns the position of the first element of the CFML delimited list that matches value,
+// where the first element is 1. The match is case-sensitive, and 0 is returned if there is no match.
+func ListFind(list, value string, opts ListOptions) int {
+	return listFind(list, opts, func(s string) bool { return s == value })
+}
+
+// ListFindNoCase is the same as ListFind, except the match ignores case.
+func ListFindNoCase(list, value string, opts ListOptions) int {
+	return listFind(list, opts, func(s string) bool { return strings.EqualFold(s, value) })
+}
+
+func listFind(list string, opts ListOptions, match func(s string) bool) int {
+	for i, s := range opts.spans(list) {
+		if match(list[s.start:s.end]) {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// ListAppend adds value to the end of the CFML delimited list, separated by the first of the delimiters.
+// When the list is empty, the value is returned without a delimiter.
+func ListAppend(list, value string, opts ListOptions) string {
+	if list == "" {
+		return value
+	}
+
+	return list + opts.delimiter() + value
+}
+
+// ListSort returns the elements of the CFML delimited list in ascending order, or descending when desc is true.
+// The sorted elements are separated by the first of the delimiters.
+func ListSort(list string, by SortType, desc bool, opts ListOptions) string {
+	elems := opts.elements(list)
+	less := func(a, b string) bool { return a < b }
+
+	switch by {
+	case SortTextNoCase:
+		less = func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) }
+	case SortNumeric:
+		less = func(a, b string) bool {
+			x, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+			y, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+
+			switch {
+			case errA == nil && errB == nil:
+				return x < y
+			case errA == nil || errB == nil:
+				return errA == nil
+			default:
+				return a < b
+			}
+		}
+	}
+
+	sort.SliceStable(elems, func(i, j int) bool {
+		if desc {
+			return less(elems[j], elems[i])
+		}
+
+		return less(elems[i], elems[j])
+	})
+
+	return strings.Join(elems, opts.delimiter())
+}
+
+// ListRemoveDuplicates returns the CFML delimited list with only the first instance of each element,
+// where the elements are compared ignoring case when noCase is true.
+// The remaining elements are separated by the first of the delimiters.
+func ListRemoveDuplicates(list string, noCase bool, opts ListOptions) string {
+	elems := opts.elements(list)
+	seen := make(map[string]bool, len(elems))
+	keep := elems[:0]
+
+	for _, e := range elems {
+		key := e
+		if noCase {
+			key = strings.ToLower(e)
+		}
+
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		keep = append(keep, e)
+	}
+
+	return strings.Join(keep, opts.delimiter())
+}
@@ -0,0 +1,148 @@
+package cfw_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleListGetAt() {
+	const list = "apple,,banana;cherry"
+	fmt.Println(cfw.ListLen(list, cfw.ListOptions{}))
+	fmt.Println(cfw.ListGetAt(list, 2, cfw.ListOptions{}))
+	fmt.Println(cfw.ListGetAt(list, 2, cfw.ListOptions{Delimiters: ",;"}))
+	fmt.Printf("%q\n", cfw.ListGetAt(list, 2, cfw.ListOptions{IncludeEmpty: true}))
+	// Output: 2
+	// banana;cherry
+	// banana
+	// ""
+}
+
+func ExampleListSort() {
+	fmt.Println(cfw.ListSort("10,9,b,100,A", cfw.SortNumeric, false, cfw.ListOptions{}))
+	fmt.Println(cfw.ListSort("b,C,a", cfw.SortTextNoCase, true, cfw.ListOptions{}))
+	// Output: 9,10,100,A,b
+	// C,b,a
+}
+
+func TestListLen(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		list string
+		opts cfw.ListOptions
+		want int
+	}{
+		{"empty", "", cfw.ListOptions{}, 0},
+		{"empty include", "", cfw.ListOptions{IncludeEmpty: true}, 0},
+		{"one", "a", cfw.ListOptions{}, 1},
+		{"skip empty", ",a,,b,", cfw.ListOptions{}, 2},
+		{"include empty", ",a,,b,", cfw.ListOptions{IncludeEmpty: true}, 5},
+		{"delimiters", "a;b c,d", cfw.ListOptions{Delimiters: "; "}, 3},
+		{"multi", "a::b:c::::d", cfw.ListOptions{Delimiters: "::", MultiCharDelimiter: true}, 3},
+		{"multi include", "a::b:c::::d", cfw.ListOptions{Delimiters: "::", MultiCharDelimiter: true, IncludeEmpty: true}, 4},
+		{"unicode", "a→b→→c", cfw.ListOptions{Delimiters: "→"}, 3},
+		{"only delimiters", ",,,", cfw.ListOptions{}, 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.ListLen(tt.list, tt.opts); got != tt.want {
+				t.Errorf("ListLen(%q) = %d, want %d", tt.list, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListElements(t *testing.T) {
+	t.Parallel()
+
+	semi := cfw.ListOptions{Delimiters: ";,"}
+	empty := cfw.ListOptions{IncludeEmpty: true}
+
+	tests := []struct {
+		name string
+		fn   func() string
+		want string
+	}{
+		{"first", func() string { return cfw.ListFirst(",,a,b", cfw.ListOptions{}) }, "a"},
+		{"first empty", func() string { return cfw.ListFirst(",,a,b", empty) }, ""},
+		{"last", func() string { return cfw.ListLast("a,b,,", cfw.ListOptions{}) }, "b"},
+		{"last empty", func() string { return cfw.ListLast("", cfw.ListOptions{}) }, ""},
+		{"rest", func() string { return cfw.ListRest("a,,b;c", semi) }, "b;c"},
+		{"rest include", func() string { return cfw.ListRest("a,,b;c", cfw.ListOptions{Delimiters: ";,", IncludeEmpty: true}) }, ",b;c"},
+		{"rest one", func() string { return cfw.ListRest("a,", cfw.ListOptions{}) }, ""},
+		{"get", func() string { return cfw.ListGetAt("a;b,c", 3, semi) }, "c"},
+		{"get range", func() string { return cfw.ListGetAt("a;b,c", 4, semi) }, ""},
+		{"get zero", func() string { return cfw.ListGetAt("a;b,c", 0, semi) }, ""},
+		{"set", func() string { return cfw.ListSetAt("a;;b,c", 2, "x", semi) }, "a;;x,c"},
+		{"set empty", func() string {
+			return cfw.ListSetAt("a;;b,c", 2, "x", cfw.ListOptions{Delimiters: ";,", IncludeEmpty: true})
+		}, "a;x;b,c"},
+		{"set range", func() string { return cfw.ListSetAt("a,b", 3, "x", cfw.ListOptions{}) }, "a,b"},
+		{"delete", func() string { return cfw.ListDeleteAt("a,,b,c", 2, cfw.ListOptions{}) }, "a,c"},
+		{"delete first", func() string { return cfw.ListDeleteAt("a,,b,c", 1, cfw.ListOptions{}) }, "b,c"},
+		{"delete last", func() string { return cfw.ListDeleteAt("a,b,c", 3, cfw.ListOptions{}) }, "a,b"},
+		{"delete only", func() string { return cfw.ListDeleteAt("a", 1, cfw.ListOptions{}) }, ""},
+		{"delete empty", func() string { return cfw.ListDeleteAt("a,,b", 2, empty) }, "a,b"},
+		{"delete first empty", func() string { return cfw.ListDeleteAt(",a,b", 1, empty) }, "a,b"},
+		{"delete range", func() string { return cfw.ListDeleteAt("a,b", 0, cfw.ListOptions{}) }, "a,b"},
+		{"append", func() string { return cfw.ListAppend("a;b", "c", semi) }, "a;b;c"},
+		{"append empty", func() string { return cfw.ListAppend("", "c", semi) }, "c"},
+		{"append multi", func() string {
+			return cfw.ListAppend("a", "b", cfw.ListOptions{Delimiters: ", ", MultiCharDelimiter: true})
+		}, "a, b"},
+		{"sort", func() string { return cfw.ListSort("b,a,,C", cfw.SortText, false, cfw.ListOptions{}) }, "C,a,b"},
+		{"sort desc", func() string { return cfw.ListSort("b;a,C", cfw.SortText, true, semi) }, "b;a;C"},
+		{"sort nocase", func() string { return cfw.ListSort("b,a,C", cfw.SortTextNoCase, false, cfw.ListOptions{}) }, "a,b,C"},
+		{"sort numeric", func() string { return cfw.ListSort("10, 9,-1.5,x", cfw.SortNumeric, false, cfw.ListOptions{}) }, "-1.5, 9,10,x"},
+		{"sort numeric desc", func() string { return cfw.ListSort("10,9,x", cfw.SortNumeric, true, cfw.ListOptions{}) }, "x,10,9"},
+		{"duplicates", func() string { return cfw.ListRemoveDuplicates("a,b,a,,A,b", false, cfw.ListOptions{}) }, "a,b,A"},
+		{"duplicates nocase", func() string { return cfw.ListRemoveDuplicates("a;b,a,A", true, semi) }, "a;b"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.fn(); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListFind(t *testing.T) {
+	t.Parallel()
+
+	const list = "apple,Banana,,cherry"
+
+	tests := []struct {
+		name   string
+		value  string
+		opts   cfw.ListOptions
+		want   int
+		noCase int
+	}{
+		{"match", "cherry", cfw.ListOptions{}, 3, 3},
+		{"case", "banana", cfw.ListOptions{}, 0, 2},
+		{"empty", "", cfw.ListOptions{}, 0, 0},
+		{"include empty", "", cfw.ListOptions{IncludeEmpty: true}, 3, 3},
+		{"include position", "cherry", cfw.ListOptions{IncludeEmpty: true}, 4, 4},
+		{"missing", "kiwi", cfw.ListOptions{}, 0, 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.ListFind(list, tt.value, tt.opts); got != tt.want {
+				t.Errorf("ListFind(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+			if got := cfw.ListFindNoCase(list, tt.value, tt.opts); got != tt.noCase {
+				t.Errorf("ListFindNoCase(%q) = %d, want %d", tt.value, got, tt.noCase)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package cfw
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+const (
+	dollarMask = ",.00"
+	groupSize  = 3
+)
+
+// NumberFormat returns x formatted using a CFML number mask, such as "9,999.99" or "(000)".
+// An empty mask rounds x to an integer with a comma separating every three digits.
+//
+// The mask can be a combination of:
+//
+//	_  optional digit placeholder
+//	9  optional digit placeholder, the same as _
+//	0  digit placeholder that pads the integer with leading zeros, or the decimals with trailing zeros
+//	.  location of the decimal point
+//	^  the same as .
+//	,  separates every three digits of the integer with a comma
+//	+  puts a plus sign before a positive number or a minus sign before a negative number
+//	-  puts a space before a positive number or a minus sign before a negative number
+//	( ) puts parentheses around a negative number, or spaces around a positive number
+//	L  left-justifies the number within the width of the mask, and must be the first character
+//	C  centers the number within the width of the mask, and must be the first character
+//	$  puts a dollar sign before the number, and must be the first character or follow L or C
+//
+// The number is right-justified and padded with spaces to the width of the mask,
+// but the integer is never cut when it has more digits than the mask.
+// Every digit placeholder after the decimal point is a decimal place,
+// and x is rounded half away from zero to the number of decimal places, as with Lucee.
+// The + and - signs are put after the number when they are at the end of the mask.
+// Any other characters in the mask are ignored.
+func NumberFormat(x float64, mask string) string {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	}
+
+	m := parseMask(mask)
+	whole, frac := round(x, m.decimals)
+	if len(whole) < m.zeros {
+		whole = strings.Repeat("0", m.zeros-len(whole)) + whole
+	}
+
+	if m.comma {
+		whole = group(whole, ",")
+	}
+
+	s := whole
+	if m.point {
+		s += "." + frac
+	}
+
+	neg := x < 0 && strings.Trim(whole+frac, "0,") != ""
+	s = m.signed(s, neg)
+
+	if m.dollar {
+		s = "$" + s
+	}
+
+	return m.justify(s)
+}
+
+// DollarFormat returns x formatted as US dollars with two decimal places and a comma separating every three digits,
+// while a negative number is put in parentheses, such as ($1,234.50).
+//
+// This function is a port of the CFML DollarFormat function.
+func DollarFormat(x float64) string {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	}
+
+	s := NumberFormat(math.Abs(x), dollarMask)
+	if x < 0 && strings.Trim(s, "0,.") != "" {
+		return "($" + s + ")"
+	}
+
+	return "$" + s
+}
+
+// CurrencyFormat returns x formatted as an amount of the currency unit using the digits and symbol of the locale,
+// such as $1,234.50 for US English or €1.234,50 for German.
+// A zero unit uses the currency of the locale's region, and the amount is rounded to the standard decimal places
+// of the currency, such as two for US dollars and none for Japanese yen.
+// A negative amount starts with a minus sign.
+//
+// The currency symbol is always put before the number,
+// as the CLDR currency patterns of each locale are not available in golang.org/x/text.
+func CurrencyFormat(x float64, unit currency.Unit, locale language.Tag) string {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	}
+
+	if unit == (currency.Unit{}) {
+		unit, _ = currency.FromTag(locale)
+	}
+
+	scale, _ := currency.Standard.Rounding(unit)
+	whole, frac := round(x, scale)
+	amount, _ := strconv.ParseFloat(whole+"."+frac, 64)
+
+	p := message.NewPrinter(locale)
+	s := p.Sprint(currency.Symbol(unit)) + p.Sprint(number.Decimal(amount, number.Scale(scale)))
+	if x < 0 && amount != 0 {
+		return "-" + s
+	}
+
+	return s
+}
+
+// numberMask is a parsed NumberFormat mask.
+type numberMask struct {
+	justify  func(s string) string
+	sign     byte // sign is either +, - or ( for the sign mask character, or 0 when there is none.
+	signEnd  bool // signEnd is true when the sign follows the digits.
+	comma    bool
+	dollar   bool
+	point    bool
+	zeros    int // zeros is the minimum number of digits of the integer.
+	decimals int // decimals is the number of decimal places.
+}
+
+func parseMask(mask string) numberMask {
+	m := numberMask{}
+	if mask == "" {
+		m.comma = true
+		m.justify = func(s string) string { return s }
+
+		return m
+	}
+
+	width, align := 0, byte('R')
+	if mask[0] == 'L' || mask[0] == 'C' {
+		align, mask = mask[0], mask[1:]
+	}
+
+	if strings.HasPrefix(mask, "$") {
+		m.dollar, mask = true, mask[1:]
+	}
+
+	digits, firstZero := 0, -1
+
+	for i := 0; i < len(mask); i++ {
+		width++
+
+		switch c := mask[i]; c {
+		case '_', '9', '0':
+			if m.point {
+				m.decimals++
+
+				continue
+			}
+
+			if c == '0' && firstZero < 0 {
+				firstZero = digits
+			}
+
+			digits++
+		case ',':
+			m.comma = true
+		case '.', '^':
+			m.point = true
+		case '+', '-':
+			m.sign, m.signEnd = c, digits > 0 || m.point
+		case '(', ')':
+			m.sign = '('
+		default:
+			width--
+		}
+	}
+
+	if firstZero >= 0 {
+		m.zeros = digits - firstZero
+	}
+
+	if m.dollar {
+		width++
+	}
+
+	m.justify = justify(width, align)
+
+	return m
+}
+
+// signed returns s with the sign of the mask.
+func (m numberMask) signed(s string, neg bool) string {
+	sign := ""
+
+	switch {
+	case m.sign == '(' && neg:
+		return "(" + s + ")"
+	case m.sign == '(':
+		return " " + s + " "
+	case neg:
+		sign = "-"
+	case m.sign == '+':
+		sign = "+"
+	case m.sign == '-':
+		sign = " "
+	}
+
+	if m.signEnd {
+		return s + sign
+	}
+
+	return sign + s
+}
+
+// justify returns a function that pads a string with spaces to the width,
+// where the align is either L for left, C for center or R for right.
+func justify(width int, align byte) func(s string) string {
+	return func(s string) string {
+		pad := width - len(s)
+		if pad <= 0 {
+			return s
+		}
+
+		switch align {
+		case 'L':
+			return s + strings.Repeat(" ", pad)
+		case 'C':
+			return strings.Repeat(" ", pad/2) + s + strings.Repeat(" ", pad-pad/2)
+		default:
+			return strings.Repeat(" ", pad) + s
+		}
+	}
+}
+
+// round returns the integer and decimal digits of the absolute value of x,
+// rounded half away from zero to the number of decimal places.
+// The rounding uses the shortest decimal representation of x, so 1.005 rounds to 1.01.
+func round(x float64, places int) (string, string) {
+	s := strconv.FormatFloat(math.Abs(x), 'f', -1, 64)
+	whole, frac, _ := strings.Cut(s, ".")
+
+	if len(frac) <= places {
+		return whole, frac + strings.Repeat("0", places-len(frac))
+	}
+
+	up := frac[places] >= '5'
+	digits := []byte(whole + frac[:places])
+
+	for i := len(digits) - 1; up && i >= 0; i-- {
+		if digits[i] == '9' {
+			digits[i] = '0'
+
+			continue
+		}
+
+		digits[i]++
+		up = false
+	}
+
+	if up {
+		digits = append([]byte{'1'}, digits...)
+	}
+
+	n := len(digits) - places
+
+	return string(digits[:n]), string(digits[n:])
+}
+
+// group returns the digits with the separator between every three digits.
+func group(digits, sep string) string {
+	if len(digits) <= groupSize {
+		return digits
+	}
+
+	var b strings.Builder
+
+	first := len(digits) % groupSize
+	if first == 0 {
+		first = groupSize
+	}
+
+	b.WriteString(digits[:first])
+
+	for i := first; i < len(digits); i += groupSize {
+		b.WriteString(sep)
+		b.WriteString(digits[i : i+groupSize])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,135 @@
+package cfw_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/bengarrett/cfw"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+func ExampleNumberFormat() {
+	fmt.Println(cfw.NumberFormat(1234.567, "9,999.99"))
+	fmt.Println(cfw.NumberFormat(-42, "(000)"))
+	fmt.Printf("%q\n", cfw.NumberFormat(5, "999"))
+	// Output: 1,234.57
+	// (042)
+	// "  5"
+}
+
+func ExampleDollarFormat() {
+	fmt.Println(cfw.DollarFormat(1234.5))
+	fmt.Println(cfw.DollarFormat(-1234.5))
+	// Output: $1,234.50
+	// ($1,234.50)
+}
+
+func ExampleCurrencyFormat() {
+	fmt.Println(cfw.CurrencyFormat(1234.5, currency.Unit{}, language.AmericanEnglish))
+	fmt.Println(cfw.CurrencyFormat(1234.5, currency.EUR, language.German))
+	// Output: $1,234.50
+	// €1.234,50
+}
+
+func TestNumberFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		x    float64
+		mask string
+		want string
+	}{
+		{"default", 1234.567, "", "1,235"},
+		{"default negative", -1234.567, "", "-1,235"},
+		{"decimals", 1234.567, "9,999.99", "1,234.57"},
+		{"pad decimals", 1.5, "__.__", " 1.50"},
+		{"zero decimals", 0.5, "_.99", "0.50"},
+		{"point", 1, "9.", "1."},
+		{"caret", 12.3, "999^9", " 12.3"},
+		{"right", 5, "999", "  5"},
+		{"left", 5, "L999", "5  "},
+		{"center", 5, "C9999", " 5  "},
+		{"zeros", 5, "000", "005"},
+		{"some zeros", 5, "_00", " 05"},
+		{"overflow", 123456789, "9,999", "123,456,789"},
+		{"dollar", 1234, "$9,999", "$1,234"},
+		{"parentheses", -5, "(999)", "  (5)"},
+		{"parentheses positive", 5, "(999)", "   5 "},
+		{"plus", 5, "+9", "+5"},
+		{"plus negative", -5, "+9", "-5"},
+		{"minus", 5, "-9", " 5"},
+		{"trailing sign", -5, "99-", " 5-"},
+		{"half up", 1.005, "9.99", "1.01"},
+		{"carry", 0.999, "9.99", "1.00"},
+		{"carry digit", 99.5, "99", "100"},
+		{"half away from zero", -2.5, "9", "-3"},
+		{"negative zero", -0.001, "9.99", "0.00"},
+		{"ignored", 5, "9x", "5"},
+		{"nan", math.NaN(), "9.99", "NaN"},
+		{"infinity", math.Inf(-1), "9.99", "-Inf"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.NumberFormat(tt.x, tt.mask); got != tt.want {
+				t.Errorf("NumberFormat(%v, %q) = %q, want %q", tt.x, tt.mask, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDollarFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		x    float64
+		want string
+	}{
+		{"zero", 0, "$0.00"},
+		{"cents", 0.5, "$0.50"},
+		{"round", 1234.567, "$1,234.57"},
+		{"negative", -1234.567, "($1,234.57)"},
+		{"negative zero", -0.001, "$0.00"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.DollarFormat(tt.x); got != tt.want {
+				t.Errorf("DollarFormat(%v) = %q, want %q", tt.x, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrencyFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		x      float64
+		unit   currency.Unit
+		locale language.Tag
+		want   string
+	}{
+		{"us", -1234.5, currency.Unit{}, language.AmericanEnglish, "-$1,234.50"},
+		{"german", 1234.5, currency.Unit{}, language.German, "€1.234,50"},
+		{"french", 1234.5, currency.EUR, language.French, "€1\u00a0234,50"},
+		{"yen", 1234.5, currency.JPY, language.Japanese, "￥1,235"},
+		{"british", 1234.5, currency.Unit{}, language.BritishEnglish, "£1,234.50"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.CurrencyFormat(tt.x, tt.unit, tt.locale); got != tt.want {
+				t.Errorf("CurrencyFormat(%v) = %q, want %q", tt.x, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,476 @@
+package cfw
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// Unit is the measure of a length limit or radius.
+type Unit int
+
+const (
+	// Runes measures the length in Unicode characters, the same as the CFWheels helpers.
+	Runes Unit = iota
+	// Bytes measures the length in bytes, such as for a database column with a byte limit.
+	// Text is never cut in the middle of a multi-byte character.
+	Bytes
+)
+
+// TruncateOptions changes the text returned by TruncateWith and WordTruncateWith.
+// The Unit, Exclusive and Boundary options are not used by WordTruncateWith.
+type TruncateOptions struct {
+	// Replace is the text appended to any truncated text, which defaults to "...".
+	Replace string
+	// Unit is the measure of the length limit, which defaults to Runes.
+	Unit Unit
+	// Exclusive does not count the Replace text toward the length limit,
+	// so the truncated text is kept at the full length before Replace is appended.
+	Exclusive bool
+	// Boundary truncates at the end of the last whole word that fits within the limit, instead of mid-word.
+	// A single word that is longer than the limit is still cut.
+	Boundary bool
+	// HTML treats the text as HTML, so only the text content counts toward the limit,
+	// markup is never cut and any elements left open by the truncation get closed.
+	// The Replace text is HTML escaped.
+	HTML bool
+}
+
+// ExcerptOptions changes the text returned by ExcerptWith.
+type ExcerptOptions struct {
+	// Replace is the text used to replace the removed text before and after the excerpt, which defaults to "...".
+	Replace string
+	// Unit is the measure of the radius, which defaults to Runes.
+	Unit Unit
+	// Boundary shrinks the excerpt to whole words, instead of cutting the first and last words of the radius.
+	Boundary bool
+	// HTML treats the text as HTML, so all markup is removed before searching for the phrase,
+	// and the excerpt including the Replace text is returned HTML escaped.
+	HTML bool
+}
+
+func (o TruncateOptions) replace() string {
+	if o.Replace == "" {
+		return ellipsis
+	}
+
+	return o.Replace
+}
+
+func (o ExcerptOptions) replace() string {
+	if o.Replace == "" {
+		return ellipsis
+	}
+
+	return o.Replace
+}
+
+// TruncateWith truncates s to the length limit n, like Truncate,
+// except the units, word boundaries and HTML handling can be configured using opts.
+// Unlike Truncate, which cuts s in bytes to keep the output of earlier versions,
+// the default unit is Runes so a multi-byte character counts once toward the limit.
+// The length of the returned text is never more than n, unless the Exclusive option is used.
+func TruncateWith(s string, n int, opts TruncateOptions) string {
+	if opts.HTML {
+		return truncateHTML(s, n, opts)
+	}
+
+	i, replace := truncateWith(s, n, opts)
+	if i < 0 {
+		return s
+	}
+
+	return s[:i] + replace
+}
+
+// WordTruncateWith truncates s to n words, like WordTruncate,
+// except the replacement and HTML handling can be configured using opts.
+// Unlike WordTruncate, which keeps the output of earlier versions,
+// s is returned unchanged when it has no more than n words, the same as CFWheels.
+func WordTruncateWith(s string, n int, opts TruncateOptions) string {
+	if opts.HTML {
+		return wordTruncateHTML(s, n, opts)
+	}
+
+	if words(s) <= n {
+		return s
+	}
+
+	b := make([]byte, 0, len(s)+len(opts.Replace)+len(ellipsis))
+
+	for i, count := 0, 0; count < n; count++ {
+		// skip the whitespace between words
+		for i < len(s) {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+
+			i += size
+		}
+
+		if count > 0 {
+			b = append(b, ' ')
+		}
+
+		start := i
+		for i < len(s) {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if unicode.IsSpace(r) {
+				break
+			}
+
+			i += size
+		}
+
+		b = append(b, s[start:i]...)
+	}
+
+	return string(append(b, opts.replace()...))
+}
+
+// ExcerptWith extracts an excerpt from s of the phrase along with a radius of n characters before and after it,
+// like Excerpt, except the units, word boundaries and HTML handling can be configured using opts.
+// Unlike Excerpt, which counts the radius in bytes to keep the output of earlier versions,
+// the default unit is Runes so a multi-byte character counts once toward the radius.
+// If the phrase is not found, an empty string is returned.
+func ExcerptWith(s, phrase string, n int, opts ExcerptOptions) string {
+	if opts.HTML {
+		s = StripTagsWith(s, StripOptions{Decode: true})
+	}
+
+	from, to, prefix, suffix, ok := excerptWith(s, phrase, n, opts)
+	if !ok {
+		return ""
+	}
+
+	if opts.HTML {
+		return html.EscapeString(prefix + s[from:to] + suffix)
+	}
+
+	return prefix + s[from:to] + suffix
+}
+
+// truncateHTML truncates the text content of the HTML s to the length limit n.
+func truncateHTML(s string, n int, opts TruncateOptions) string {
+	total := 0
+
+	walkHTML(s, "", func(text string) int {
+		total += count(text, opts.Unit)
+
+		return -1
+	})
+
+	if total <= n {
+		return s
+	}
+
+	replace := opts.replace()
+
+	limit := n
+	if !opts.Exclusive {
+		limit -= count(replace, opts.Unit)
+	}
+
+	if limit < 0 {
+		// the replacement alone is longer than the limit
+		return html.EscapeString(replace[:offset(replace, n, opts.Unit)])
+	}
+
+	space := true // space is true when the previous text ended with whitespace.
+
+	return walkHTML(s, replace, func(text string) int {
+		c := count(text, opts.Unit)
+		if c <= limit {
+			limit -= c
+
+			if text != "" {
+				r, _ := utf8.DecodeLastRuneInString(text)
+				space = unicode.IsSpace(r)
+			}
+
+			return -1
+		}
+
+		i := offset(text, limit, opts.Unit)
+		if opts.Boundary {
+			j, ok := wordEnd(text, 0, i)
+
+			switch {
+			case ok:
+				i = j
+			case space:
+				// the word began at the start of this text
+				i = 0
+			}
+		}
+
+		return i
+	})
+}
+
+// wordTruncateHTML truncates the text content of the HTML s to n words.
+func wordTruncateHTML(s string, n int, opts TruncateOptions) string {
+	total := 0
+
+	walkHTML(s, "", func(text string) int {
+		total += words(text)
+
+		return -1
+	})
+
+	if total <= n {
+		return s
+	}
+
+	limit := n
+
+	return walkHTML(s, opts.replace(), func(text string) int {
+		w := words(text)
+		if w == 0 || w < limit {
+			limit -= w
+
+			return -1
+		}
+
+		return nthWord(text, limit)
+	})
+}
+
+// walkHTML returns the HTML s truncated within the first text for which cut returns an offset,
+// with the replace text appended and any elements left open closed.
+// The cut function is given the decoded text of each text token, except for script and style elements,
+// and returns the byte offset of the text to keep, or -1 to keep all the text.
+// If cut never returns an offset, s is returned.
+func walkHTML(s, replace string, cut func(text string) int) string {
+	var (
+		b    strings.Builder
+		open []string // open is the stack of elements that are yet to be closed.
+		raw  string   // raw is the name of the element containing the next text token.
+		tag  []byte
+	)
+
+	b.Grow(len(s))
+
+	z := nethtml.NewTokenizer(strings.NewReader(s))
+
+	for {
+		tt := z.Next()
+
+		switch tt {
+		case nethtml.ErrorToken:
+			return s
+		case nethtml.TextToken:
+			if raw == "script" || raw == "style" {
+				b.Write(z.Raw())
+
+				continue
+			}
+
+			text := string(z.Text())
+
+			i := cut(text)
+			if i < 0 {
+				b.Write(z.Raw())
+
+				continue
+			}
+
+			b.WriteString(html.EscapeString(text[:i]))
+			b.WriteString(html.EscapeString(replace))
+
+			for j := len(open) - 1; j >= 0; j-- {
+				b.WriteString("</" + open[j] + ">")
+			}
+
+			return b.String()
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken, nethtml.EndTagToken:
+			// TagName lowercases the raw tag, so it must be copied first
+			tag = append(tag[:0], z.Raw()...)
+			name, _ := z.TagName()
+			raw = ""
+
+			switch {
+			case tt == nethtml.StartTagToken && !voids[string(name)]:
+				open = append(open, string(name))
+				raw = string(name)
+			case tt == nethtml.EndTagToken:
+				for i := len(open) - 1; i >= 0; i-- {
+					if open[i] == string(name) {
+						open = open[:i]
+
+						break
+					}
+				}
+			}
+
+			b.Write(tag)
+		case nethtml.CommentToken, nethtml.DoctypeToken:
+			raw = ""
+
+			b.Write(z.Raw())
+		}
+	}
+}
+
+// count returns the length of s in the unit.
+func count[T Text](s T, u Unit) int {
+	if u == Bytes {
+		return len(s)
+	}
+
+	return runeCount(s)
+}
+
+// offset returns the byte offset of s after the first n units, without splitting a character.
+func offset[T Text](s T, n int, u Unit) int {
+	if n <= 0 {
+		return 0
+	}
+
+	if u == Bytes {
+		if n >= len(s) {
+			return len(s)
+		}
+
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+
+		return n
+	}
+
+	i := 0
+	for ; i < len(s) && n > 0; n-- {
+		_, size := decodeRune(s[i:])
+		i += size
+	}
+
+	return i
+}
+
+// offsetLast returns the byte offset of s before the last n units, without splitting a character.
+func offsetLast[T Text](s T, n int, u Unit) int {
+	if n <= 0 {
+		return len(s)
+	}
+
+	if u == Bytes {
+		i := len(s) - n
+		if i <= 0 {
+			return 0
+		}
+
+		for i < len(s) && !utf8.RuneStart(s[i]) {
+			i++
+		}
+
+		return i
+	}
+
+	i := len(s)
+	for ; i > 0 && n > 0; n-- {
+		_, size := decodeLastRune(s[:i])
+		i -= size
+	}
+
+	return i
+}
+
+// wordEnd returns the offset i of s moved back to the end of the last whole word, but not before floor.
+// If there is no whitespace between floor and i to break at, then ok is false.
+func wordEnd[T Text](s T, floor, i int) (int, bool) {
+	if i >= len(s) || i <= floor {
+		return i, true
+	}
+
+	if r, _ := decodeRune(s[i:]); !unicode.IsSpace(r) {
+		// the offset is within a word, so drop the partial word
+		j := i
+		for j > floor {
+			r, size := decodeLastRune(s[:j])
+			if unicode.IsSpace(r) {
+				break
+			}
+
+			j -= size
+		}
+
+		if j == floor {
+			return i, false
+		}
+
+		i = j
+	}
+
+	for i > floor {
+		r, size := decodeLastRune(s[:i])
+		if !unicode.IsSpace(r) {
+			break
+		}
+
+		i -= size
+	}
+
+	return i, true
+}
+
+// wordStart returns the offset i of s moved forward to the start of the next whole word, but not after ceil.
+func wordStart[T Text](s T, i, ceil int) int {
+	if i <= 0 || i >= ceil {
+		return i
+	}
+
+	if r, _ := decodeLastRune(s[:i]); !unicode.IsSpace(r) {
+		// the offset is within a word, so drop the partial word
+		for i < ceil {
+			r, size := decodeRune(s[i:])
+			if unicode.IsSpace(r) {
+				break
+			}
+
+			i += size
+		}
+	}
+
+	for i < ceil {
+		r, size := decodeRune(s[i:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+
+		i += size
+	}
+
+	return i
+}
+
+// nthWord returns the byte offset of s after the end of the nth word.
+func nthWord[T Text](s T, n int) int {
+	i := 0
+
+	for ; n > 0 && i < len(s); n-- {
+		for i < len(s) {
+			r, size := decodeRune(s[i:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+
+			i += size
+		}
+
+		for i < len(s) {
+			r, size := decodeRune(s[i:])
+			if unicode.IsSpace(r) {
+				break
+			}
+
+			i += size
+		}
+	}
+
+	return i
+}
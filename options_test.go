@@ -0,0 +1,167 @@
+package cfw_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleTruncateWith() {
+	const s = "Go is an open source programming language"
+	fmt.Println(cfw.TruncateWith(s, 15, cfw.TruncateOptions{}))
+	fmt.Println(cfw.TruncateWith(s, 15, cfw.TruncateOptions{Boundary: true}))
+	fmt.Println(cfw.TruncateWith(s, 15, cfw.TruncateOptions{Boundary: true, Exclusive: true}))
+	fmt.Println(cfw.TruncateWith("<p>Go is an <b>open source</b> language</p>", 16, cfw.TruncateOptions{HTML: true}))
+	// Output: Go is an ope...
+	// Go is an...
+	// Go is an open...
+	// <p>Go is an <b>open...</b></p>
+}
+
+func TestTruncateWith(t *testing.T) {
+	t.Parallel()
+
+	const (
+		s     = "this is a test to see if this works or not."
+		emoji = "The quick brown 🦊 jumps over the lazy 🐕"
+		para  = `<p>This is <a href="/test">a test</a> to see if <em>this works</em> or not.</p>`
+	)
+
+	type args struct {
+		s    string
+		n    int
+		opts cfw.TruncateOptions
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"default", args{s, 20, cfw.TruncateOptions{}}, "this is a test to..."},
+		{"fits", args{s, 100, cfw.TruncateOptions{Boundary: true, HTML: true}}, s},
+		{"replace", args{s, 20, cfw.TruncateOptions{Replace: "[more]"}}, "this is a test[more]"},
+		{"exclusive", args{s, 20, cfw.TruncateOptions{Exclusive: true}}, "this is a test to se..."},
+		{"boundary", args{s, 19, cfw.TruncateOptions{Boundary: true}}, "this is a test..."},
+		{"boundary space", args{s, 17, cfw.TruncateOptions{Boundary: true}}, "this is a test..."},
+		{"boundary word", args{"antidisestablishmentarianism", 10, cfw.TruncateOptions{Boundary: true}}, "antidis..."},
+		{"runes", args{emoji, 19, cfw.TruncateOptions{Replace: "💬"}}, "The quick brown 🦊 💬"},
+		{"bytes", args{emoji, 24, cfw.TruncateOptions{Replace: "💬", Unit: cfw.Bytes}}, "The quick brown 🦊💬"},
+		{"bytes mid-rune", args{emoji, 21, cfw.TruncateOptions{Replace: "💬", Unit: cfw.Bytes}}, "The quick brown 💬"},
+		{"limit too small", args{s, 2, cfw.TruncateOptions{}}, ".."},
+		{"html", args{para, 20, cfw.TruncateOptions{HTML: true}}, `<p>This is <a href="/test">a test</a> to...</p>`},
+		{"html open", args{para, 12, cfw.TruncateOptions{HTML: true}}, `<p>This is <a href="/test">a...</a></p>`},
+		{"html boundary", args{para, 36, cfw.TruncateOptions{HTML: true, Boundary: true}},
+			`<p>This is <a href="/test">a test</a> to see if <em>this...</em></p>`},
+		{"html boundary tag", args{para, 31, cfw.TruncateOptions{HTML: true, Boundary: true}},
+			`<p>This is <a href="/test">a test</a> to see if <em>...</em></p>`},
+		{"html entities", args{"<b>Fish &amp; chips &amp; peas</b>", 12, cfw.TruncateOptions{HTML: true}},
+			"<b>Fish &amp; ch...</b>"},
+		{"html script", args{"<script>let x = 1;</script>Hello world", 8, cfw.TruncateOptions{HTML: true}},
+			"<script>let x = 1;</script>Hello..."},
+		{"html void", args{"Hello<br>world and everyone", 13, cfw.TruncateOptions{HTML: true}}, "Hello<br>world..."},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.TruncateWith(tt.args.s, tt.args.n, tt.args.opts); got != tt.want {
+				t.Errorf("TruncateWith() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleWordTruncateWith() {
+	fmt.Println(cfw.WordTruncateWith("<p>Go is an <b>open source</b> language</p>", 4,
+		cfw.TruncateOptions{HTML: true}))
+	// Output: <p>Go is an <b>open...</b></p>
+}
+
+func TestWordTruncateWith(t *testing.T) {
+	t.Parallel()
+
+	const para = `<p>This is <a href="/test">a test</a> to see if <em>this works</em> or not.</p>`
+
+	type args struct {
+		s    string
+		n    int
+		opts cfw.TruncateOptions
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"default", args{"CFWheels is a framework for ColdFusion", 4, cfw.TruncateOptions{}}, "CFWheels is a framework..."},
+		{"whitespace", args{"  CFWheels   is\ta framework ", 2, cfw.TruncateOptions{Replace: "!"}}, "CFWheels is!"},
+		{"fewer", args{"CFWheels is a framework", 10, cfw.TruncateOptions{}}, "CFWheels is a framework"},
+		{"zero", args{"CFWheels is a framework", 0, cfw.TruncateOptions{}}, "..."},
+		{"html", args{para, 4, cfw.TruncateOptions{HTML: true}}, `<p>This is <a href="/test">a test...</a></p>`},
+		{"html text", args{para, 3, cfw.TruncateOptions{HTML: true}}, `<p>This is <a href="/test">a...</a></p>`},
+		{"html tag end", args{para, 2, cfw.TruncateOptions{HTML: true}}, `<p>This is...</p>`},
+		{"html zero", args{para, 0, cfw.TruncateOptions{HTML: true}}, `<p>...</p>`},
+		{"html fits", args{para, 12, cfw.TruncateOptions{HTML: true}}, para},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.WordTruncateWith(tt.args.s, tt.args.n, tt.args.opts); got != tt.want {
+				t.Errorf("WordTruncateWith() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleExcerptWith() {
+	const s = "CFWheels: testing the excerpt view helper to see if it works or not."
+	fmt.Println(cfw.ExcerptWith(s, "excerpt view helper", 10, cfw.ExcerptOptions{}))
+	fmt.Println(cfw.ExcerptWith(s, "excerpt view helper", 10, cfw.ExcerptOptions{Boundary: true}))
+	// Output: ...sting the excerpt view helper to see if...
+	// ...the excerpt view helper to see if...
+}
+
+func TestExcerptWith(t *testing.T) {
+	t.Parallel()
+
+	const (
+		s     = "CFWheels: testing the excerpt view helper to see if it works or not."
+		emoji = "The quick brown 🦊 jumps over the lazy 🐕"
+	)
+
+	type args struct {
+		s      string
+		phrase string
+		n      int
+		opts   cfw.ExcerptOptions
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"default", args{s, "excerpt view helper", 10, cfw.ExcerptOptions{}}, "...sting the excerpt view helper to see if..."},
+		{"missing", args{s, "jklsduiermobk", 10, cfw.ExcerptOptions{HTML: true}}, ""},
+		{"boundary", args{s, "view", 7, cfw.ExcerptOptions{Boundary: true, Replace: "[more]"}}, "[more]view helper[more]"},
+		{"boundary start", args{s, "view", 14, cfw.ExcerptOptions{Boundary: true}}, "...the excerpt view helper to see..."},
+		{"runes", args{emoji, "jumps", 3, cfw.ExcerptOptions{}}, "... 🦊 jumps ov..."},
+		{"bytes", args{emoji, "jumps", 3, cfw.ExcerptOptions{Unit: cfw.Bytes}}, "... jumps ov..."},
+		{"html", args{"<p>Fish &amp; <b>chips</b> &amp; peas, please</p>", "chips", 4, cfw.ExcerptOptions{HTML: true}},
+			"...h &amp; chips &amp; p..."},
+		{"html escape", args{"1 < 2 <b>or</b> 3 > 2 in maths", "or", 4, cfw.ExcerptOptions{HTML: true}},
+			"...&lt; 2 or 3 &gt;..."},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.ExcerptWith(tt.args.s, tt.args.phrase, tt.args.n, tt.args.opts); got != tt.want {
+				t.Errorf("ExcerptWith() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
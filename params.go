@@ -0,0 +1,459 @@
+package cfw
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// checkbox is the suffix of the hidden field posted by the CFWheels checkBox helpers,
+// which holds the value of an unchecked box.
+const checkbox = "($checkbox)"
+
+// ErrParams is returned when a param cannot be decoded into a Go value.
+var ErrParams = errors.New("invalid param")
+
+// ParamsOptions changes the params returned by ParseParams and DecodeParams.
+type ParamsOptions struct {
+	// Obfuscate deobfuscates the key param using DeObfuscate,
+	// the same as a CFWheels application with the obfuscateURLs setting enabled.
+	Obfuscate bool
+}
+
+// ParseParams returns the nested params of the form or query values, the same as the CFWheels params struct.
+//
+// A name with square brackets is a nested map, so user[name]=Ada&user[address][city]=Paris returns
+// {"user": {"name": "Ada", "address": {"city": "Paris"}}}, while empty brackets such as tags[] are ignored.
+// A name that is posted more than once has its values joined with commas, the same as a CFML form field.
+// The hidden ($checkbox) field of the CFWheels checkBox helpers is used as the value of an unchecked box,
+// and is then removed.
+// The ($year), ($month), ($day), ($hour), ($minute), ($second) and ($ampm) fields of the CFWheels dateSelect
+// and timeSelect helpers are combined into a UTC time.Time, so user[birthday]($year)=1815&user[birthday]($month)=12
+// returns {"user": {"birthday": 1815-12-01 00:00:00 +0000 UTC}}.
+// A missing year is 1899, a missing month or day is 1, and a date that doesn't exist is an empty string.
+// The suffixes of the fields are matched ignoring case.
+//
+// This function is a port of the CFWheels $createParams function.
+func ParseParams(values url.Values, opts ParamsOptions) map[string]interface{} {
+	flat := checkboxParams(values)
+	dateParams(flat)
+
+	names := make([]string, 0, len(flat))
+	for name := range flat {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	params := map[string]interface{}{}
+
+	for _, name := range names {
+		setParam(params, paramPath(name), flat[name])
+	}
+
+	if key, ok := params["key"].(string); ok && opts.Obfuscate {
+		params["key"] = DeObfuscate(key)
+	}
+
+	return params
+}
+
+// DecodeParams parses the form or query values using ParseParams and stores the params in v,
+// which must be a pointer to a struct or a map with string keys.
+//
+// A struct field uses the name of its param tag, otherwise the field name, which matches the param ignoring case,
+// and a field with the param tag "-" is skipped.
+// A nested map is stored in a struct or map field. A string is stored in a string, bool, integer or float field,
+// a field that implements encoding.TextUnmarshaler such as UUID, or a time.Time field
+// using either the RFC 3339 or the 2006-01-02 format.
+// A slice field is set from the comma separated list of the param.
+// An error is returned if a param cannot be stored in its field.
+func DecodeParams(values url.Values, v interface{}, opts ParamsOptions) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("decode params %T: %w", v, ErrParams)
+	}
+
+	return decodeParam("params", ParseParams(values, opts), rv.Elem())
+}
+
+// checkboxParams returns the values as flat params, with the values of a name joined with commas.
+// A name with the ($checkbox) suffix is renamed without it, unless the checkbox is checked,
+// where the name is matched ignoring case the same as a CFML struct key.
+func checkboxParams(values url.Values) map[string]interface{} {
+	posted := make(map[string]bool, len(values))
+	for name := range values {
+		posted[strings.ToLower(name)] = true
+	}
+
+	flat := make(map[string]interface{}, len(values))
+
+	for name, vals := range values {
+		value := strings.Join(vals, ",")
+
+		if i, j := indexFold(name, checkbox); i >= 0 {
+			base := name[:i] + name[j:]
+			if posted[strings.ToLower(base)] {
+				continue
+			}
+
+			name = base
+		}
+
+		flat[name] = value
+	}
+
+	return flat
+}
+
+// dateParts are the suffixes of the date and time select fields, and the value used when a part is missing.
+var dateParts = map[string]int{
+	"year": 1899, "month": 1, "day": 1, "hour": 0, "minute": 0, "second": 0, "ampm": 0,
+}
+
+// dateParams replaces the date and time part fields of the flat params with a time.Time,
+// or with an empty string when the parts are not a valid date,
+// the same as the CFWheels $translateDatePartSubmissions function.
+func dateParams(flat map[string]interface{}) {
+	dates := map[string]map[string]string{}
+
+	for name, value := range flat {
+		base, part, ok := datePart(name)
+		if !ok {
+			continue
+		}
+
+		if dates[base] == nil {
+			dates[base] = map[string]string{}
+		}
+
+		dates[base][part], _ = value.(string)
+
+		delete(flat, name)
+	}
+
+	for base, parts := range dates {
+		flat[base] = ""
+
+		if t, ok := partsTime(parts); ok {
+			flat[base] = t
+		}
+	}
+}
+
+// datePart returns the param name and the lowercase part of a date part field, such as user[birthday]($year).
+func datePart(name string) (string, string, bool) {
+	i := strings.LastIndex(name, "($")
+	if i < 0 || !strings.HasSuffix(name, ")") {
+		return "", "", false
+	}
+
+	part := strings.ToLower(name[i+2 : len(name)-1])
+	if _, ok := dateParts[part]; !ok {
+		return "", "", false
+	}
+
+	return name[:i], part, true
+}
+
+// partsTime returns the UTC time of the date parts, where a missing part uses its value in dateParts.
+// It returns false if a part is not an integer or the date doesn't exist, such as the 31st of February.
+func partsTime(parts map[string]string) (time.Time, bool) {
+	n := make(map[string]int, len(dateParts))
+
+	for part, value := range dateParts {
+		s, ok := parts[part]
+		if !ok || part == "ampm" {
+			n[part] = value
+
+			continue
+		}
+
+		i, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		n[part] = i
+	}
+
+	const noon = 12
+
+	switch strings.ToUpper(strings.TrimSpace(parts["ampm"])) {
+	case "AM":
+		if n["hour"] == noon {
+			n["hour"] = 0
+		}
+	case "PM":
+		if n["hour"] != noon {
+			n["hour"] += noon
+		}
+	}
+
+	t := time.Date(n["year"], time.Month(n["month"]), n["day"], n["hour"], n["minute"], n["second"], 0, time.UTC)
+	if t.Year() != n["year"] || int(t.Month()) != n["month"] || t.Day() != n["day"] ||
+		t.Hour() != n["hour"] || t.Minute() != n["minute"] || t.Second() != n["second"] {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// paramPath returns the names of a nested param, where user[address][city] is user, address and city.
+// A name without valid brackets, such as user[name or user[name]x, is not nested.
+func paramPath(name string) []string {
+	base, rest, ok := strings.Cut(name, "[")
+	if !ok || base == "" || !strings.HasSuffix(rest, "]") {
+		return []string{name}
+	}
+
+	path := []string{base}
+
+	for _, s := range strings.Split(strings.TrimSuffix(rest, "]"), "][") {
+		if strings.ContainsAny(s, "[]") {
+			return []string{name}
+		}
+
+		if s != "" {
+			path = append(path, s)
+		}
+	}
+
+	return path
+}
+
+// setParam stores the value in the nested params at the path, where a nested map replaces a value.
+func setParam(params map[string]interface{}, path []string, value interface{}) {
+	for _, name := range path[:len(path)-1] {
+		m, ok := params[name].(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{}
+			params[name] = m
+		}
+
+		params = m
+	}
+
+	name := path[len(path)-1]
+	if _, ok := params[name].(map[string]interface{}); ok {
+		return
+	}
+
+	params[name] = value
+}
+
+// decodeParam stores the param in v, where name is the param name used in an error.
+func decodeParam(name string, param interface{}, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		return decodeParam(name, param, v.Elem())
+	}
+
+	if m, ok := param.(map[string]interface{}); ok {
+		switch v.Kind() {
+		case reflect.Struct:
+			return decodeStruct(m, v)
+		case reflect.Map:
+			return decodeMap(name, m, v)
+		case reflect.Interface:
+			if v.NumMethod() == 0 {
+				v.Set(reflect.ValueOf(m))
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("decode param %q to %s: %w", name, v.Type(), ErrParams)
+	}
+
+	if t, ok := param.(time.Time); ok {
+		if tv := reflect.ValueOf(t); tv.Type().AssignableTo(v.Type()) {
+			v.Set(tv)
+
+			return nil
+		}
+
+		param = t.Format(time.RFC3339)
+	}
+
+	s, _ := param.(string)
+
+	if err := decodeValue(s, v); err != nil {
+		return fmt.Errorf("decode param %q to %s: %w", name, v.Type(), err)
+	}
+
+	return nil
+}
+
+func decodeStruct(m map[string]interface{}, v reflect.Value) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("param"); ok {
+			if tag == "-" {
+				continue
+			}
+
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		for key, param := range m {
+			if !strings.EqualFold(key, name) {
+				continue
+			}
+
+			if err := decodeParam(key, param, v.Field(i)); err != nil {
+				return err
+			}
+
+			break
+		}
+	}
+
+	return nil
+}
+
+func decodeMap(name string, m map[string]interface{}, v reflect.Value) error {
+	t := v.Type()
+	if t.Key().Kind() != reflect.String {
+		return fmt.Errorf("decode param %q to %s: %w", name, t, ErrParams)
+	}
+
+	if v.IsNil() {
+		v.Set(reflect.MakeMapWithSize(t, len(m)))
+	}
+
+	for key, param := range m {
+		elem := reflect.New(t.Elem()).Elem()
+		if err := decodeParam(key, param, elem); err != nil {
+			return err
+		}
+
+		v.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), elem)
+	}
+
+	return nil
+}
+
+// decodeValue stores the string param s in v.
+func decodeValue(s string, v reflect.Value) error {
+	if _, ok := v.Interface().(time.Time); ok {
+		return decodeTime(s, v)
+	}
+
+	if v.CanAddr() {
+		if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := u.UnmarshalText([]byte(s)); err != nil {
+				return fmt.Errorf("%w: %s", ErrParams, err)
+			}
+
+			return nil
+		}
+	}
+
+	var err error
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		var b bool
+		if b, err = parseBool(s); err == nil {
+			v.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if i, err = strconv.ParseInt(strings.TrimSpace(s), decimal, v.Type().Bits()); err == nil {
+			v.SetInt(i)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		if u, err = strconv.ParseUint(strings.TrimSpace(s), decimal, v.Type().Bits()); err == nil {
+			v.SetUint(u)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(strings.TrimSpace(s), v.Type().Bits()); err == nil {
+			v.SetFloat(f)
+		}
+	case reflect.Slice:
+		return decodeSlice(s, v)
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return ErrParams
+		}
+
+		v.Set(reflect.ValueOf(s))
+	default:
+		return ErrParams
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrParams, err)
+	}
+
+	return nil
+}
+
+// decodeSlice stores the comma separated list s in v, where an empty string is an empty slice.
+func decodeSlice(s string, v reflect.Value) error {
+	items := []string{}
+	if s != "" {
+		items = strings.Split(s, ",")
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+	for i, item := range items {
+		if err := decodeValue(item, slice.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	v.Set(slice)
+
+	return nil
+}
+
+func decodeTime(s string, v reflect.Value) error {
+	s = strings.TrimSpace(s)
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			v.Set(reflect.ValueOf(t))
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: time %q", ErrParams, s)
+}
+
+// parseBool returns the boolean of a CFML string, which is also yes or no, or the on and off of an HTML checkbox,
+// and where an empty string is false.
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "no", "off":
+		return false, nil
+	case "yes", "on":
+		return true, nil
+	default:
+		return strconv.ParseBool(s)
+	}
+}
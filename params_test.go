@@ -0,0 +1,239 @@
+package cfw_test
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleParseParams() {
+	values, _ := url.ParseQuery("key=a01b1&user[name]=Ada&user[address][city]=Paris")
+	params := cfw.ParseParams(values, cfw.ParamsOptions{Obfuscate: true})
+	fmt.Println(params)
+	// Output: map[key:42 user:map[address:map[city:Paris] name:Ada]]
+}
+
+func ExampleDecodeParams() {
+	type address struct {
+		City string
+	}
+
+	var form struct {
+		Key  int
+		User struct {
+			Name    string `param:"fullName"`
+			Admin   bool
+			Address address
+		}
+	}
+
+	values, _ := url.ParseQuery("key=a01b1&user[fullname]=Ada&user[admin]($checkbox)=0&user[address][city]=Paris")
+	_ = cfw.DecodeParams(values, &form, cfw.ParamsOptions{Obfuscate: true})
+	fmt.Printf("%+v\n", form)
+	// Output: {Key:42 User:{Name:Ada Admin:false Address:{City:Paris}}}
+}
+
+func TestParseParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		query string
+		opts  cfw.ParamsOptions
+		want  map[string]interface{}
+	}{
+		{"empty", "", cfw.ParamsOptions{}, map[string]interface{}{}},
+		{"flat", "a=1&b=2", cfw.ParamsOptions{}, map[string]interface{}{"a": "1", "b": "2"}},
+		{"list", "a=1&a=2", cfw.ParamsOptions{}, map[string]interface{}{"a": "1,2"}},
+		{
+			"nested", "user[name]=Ada&user[address][city]=Paris&user[address][zip]=75001", cfw.ParamsOptions{},
+			map[string]interface{}{"user": map[string]interface{}{
+				"name": "Ada", "address": map[string]interface{}{"city": "Paris", "zip": "75001"},
+			}},
+		},
+		{"empty brackets", "tags[]=a&tags[]=b", cfw.ParamsOptions{}, map[string]interface{}{"tags": "a,b"}},
+		{
+			"not nested", "a[b=1&[c]=2&d[e]f=3&g[h[i]]=4", cfw.ParamsOptions{},
+			map[string]interface{}{"a[b": "1", "[c]": "2", "d[e]f": "3", "g[h[i]]": "4"},
+		},
+		{
+			"nested replaces string", "user=x&user[name]=Ada", cfw.ParamsOptions{},
+			map[string]interface{}{"user": map[string]interface{}{"name": "Ada"}},
+		},
+		{
+			"checked", "user[admin]=1&user[admin]($checkbox)=0", cfw.ParamsOptions{},
+			map[string]interface{}{"user": map[string]interface{}{"admin": "1"}},
+		},
+		{
+			"unchecked", "user[admin]($checkbox)=0&agree($checkbox)=no", cfw.ParamsOptions{},
+			map[string]interface{}{"agree": "no", "user": map[string]interface{}{"admin": "0"}},
+		},
+		{
+			"checked case", "User[Admin]=1&user[admin]($CHECKBOX)=0", cfw.ParamsOptions{},
+			map[string]interface{}{"User": map[string]interface{}{"Admin": "1"}},
+		},
+		{"unchecked case", "agree($CheckBox)=no", cfw.ParamsOptions{}, map[string]interface{}{"agree": "no"}},
+		{
+			"date", "user[born]($year)=1815&user[born]($month)=12&user[born]($day)=10", cfw.ParamsOptions{},
+			map[string]interface{}{"user": map[string]interface{}{
+				"born": time.Date(1815, time.December, 10, 0, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			"time", "start($hour)=3&start($minute)=30&start($second)=15&start($ampm)=pm", cfw.ParamsOptions{},
+			map[string]interface{}{"start": time.Date(1899, time.January, 1, 15, 30, 15, 0, time.UTC)},
+		},
+		{
+			"midnight", "start($hour)=12&start($ampm)=AM", cfw.ParamsOptions{},
+			map[string]interface{}{"start": time.Date(1899, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			"date case", "day($YEAR)=2000&day($Month)=2", cfw.ParamsOptions{},
+			map[string]interface{}{"day": time.Date(2000, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			"invalid date", "day($year)=2026&day($month)=2&day($day)=31", cfw.ParamsOptions{},
+			map[string]interface{}{"day": ""},
+		},
+		{"not a number", "day($year)=soon", cfw.ParamsOptions{}, map[string]interface{}{"day": ""}},
+		{"not a part", "day($week)=1", cfw.ParamsOptions{}, map[string]interface{}{"day($week)": "1"}},
+		{"key", "key=a01b1", cfw.ParamsOptions{}, map[string]interface{}{"key": "a01b1"}},
+		{"obfuscated key", "key=a01b1", cfw.ParamsOptions{Obfuscate: true}, map[string]interface{}{"key": "42"}},
+		{"plain key", "key=42", cfw.ParamsOptions{Obfuscate: true}, map[string]interface{}{"key": "42"}},
+		{
+			"nested key", "user[key]=a01b1", cfw.ParamsOptions{Obfuscate: true},
+			map[string]interface{}{"user": map[string]interface{}{"key": "a01b1"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cfw.ParseParams(values, tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeParams(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		City string
+		Zip  *int
+	}
+
+	type user struct {
+		ID      cfw.UUID
+		Name    string
+		Age     uint8
+		Score   float64
+		Admin   bool
+		Born    time.Time
+		Tags    []string
+		Ranks   []int
+		Address address
+		Extra   map[string]string
+		Other   interface{}
+		Joined  time.Time
+		Seen    string
+		Secret  string `param:"-"`
+	}
+
+	type form struct {
+		Key  int
+		User *user
+	}
+
+	const query = "key=a01b1&user[id]=7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F&user[name]=Ada&user[age]=36" +
+		"&user[score]=1.5&user[admin]=yes&user[born]=1815-12-10&user[tags]=a&user[tags]=b&user[ranks]=1,2" +
+		"&user[address][city]=London&user[address][zip]=42&user[extra][x]=1&user[other][y]=2&user[secret]=s" +
+		"&user[joined]($year)=2026&user[joined]($month)=1&user[joined]($day)=5&user[joined]($hour)=3" +
+		"&user[joined]($ampm)=PM&user[seen]($year)=2000"
+
+	id, _ := cfw.ParseUUID("7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F")
+	zip := 42
+	want := form{Key: 42, User: &user{
+		ID: id, Name: "Ada", Age: 36, Score: 1.5, Admin: true,
+		Born:    time.Date(1815, time.December, 10, 0, 0, 0, 0, time.UTC),
+		Tags:    []string{"a", "b"},
+		Ranks:   []int{1, 2},
+		Address: address{City: "London", Zip: &zip},
+		Extra:   map[string]string{"x": "1"},
+		Other:   map[string]interface{}{"y": "2"},
+		Joined:  time.Date(2026, time.January, 5, 15, 0, 0, 0, time.UTC),
+		Seen:    "2000-01-01T00:00:00Z",
+	}}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got form
+	if err := cfw.DecodeParams(values, &got, cfw.ParamsOptions{Obfuscate: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeParams() = %+v, want %+v", got.User, want.User)
+	}
+}
+
+func TestDecodeParamsErrors(t *testing.T) {
+	t.Parallel()
+
+	type fields struct {
+		N     int
+		B     bool
+		T     time.Time
+		U     cfw.UUID
+		S     struct{ X string }
+		C     chan int
+		Ranks []int
+	}
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"int", "n=one"},
+		{"overflow", "n=99999999999999999999"},
+		{"bool", "b=maybe"},
+		{"time", "t=yesterday"},
+		{"uuid", "u=123"},
+		{"struct", "s=x"},
+		{"map to int", "n[x]=1"},
+		{"chan", "c=1"},
+		{"slice", "ranks=1,x"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var v fields
+			if err := cfw.DecodeParams(values, &v, cfw.ParamsOptions{}); !errors.Is(err, cfw.ErrParams) {
+				t.Errorf("DecodeParams() error = %v, want %v", err, cfw.ErrParams)
+			}
+		})
+	}
+
+	var v fields
+	if err := cfw.DecodeParams(url.Values{}, v, cfw.ParamsOptions{}); !errors.Is(err, cfw.ErrParams) {
+		t.Errorf("DecodeParams() of a non-pointer error = %v, want %v", err, cfw.ErrParams)
+	}
+}
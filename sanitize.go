@@ -0,0 +1,265 @@
+package cfw
+
+import (
+	"html"
+	"net/url"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// Policy is an allowlist of the HTML elements, attributes and URL schemes that are kept by Sanitize.
+type Policy struct {
+	// Elements maps the lowercase names of the allowed HTML elements to their allowed attributes.
+	Elements map[string][]string
+	// Schemes are the allowed URL schemes for the href, src and cite attributes, such as "https".
+	// Relative URLs are always allowed, while any other URL is removed if Schemes is empty.
+	Schemes []string
+	// Rel is the forced rel attribute value for every link with a href, such as "nofollow".
+	// An empty Rel keeps any allowed rel attribute.
+	Rel string
+}
+
+// WheelsPolicy returns a Policy that allows common text formatting elements,
+// plus the markup created by the CFWheels helpers, such as the paragraphs and
+// line breaks of SimpleFormat and the links of AutoLink.
+// Links must use the http, https or mailto schemes and are forced to use rel="nofollow".
+func WheelsPolicy() Policy {
+	return Policy{
+		Elements: map[string][]string{
+			"a":          {"href", "title", "target"},
+			"b":          nil,
+			"blockquote": nil,
+			"br":         nil,
+			"code":       nil,
+			"em":         nil,
+			"i":          nil,
+			"li":         nil,
+			"ol":         nil,
+			"p":          nil,
+			"pre":        nil,
+			"span":       {"class"},
+			"strong":     nil,
+			"u":          nil,
+			"ul":         nil,
+		},
+		Schemes: []string{"http", "https", "mailto"},
+		Rel:     "nofollow",
+	}
+}
+
+// Sanitize removes all the HTML elements, attributes and URLs from s that are not allowed by the policy.
+// The contents of removed elements are kept, except for the elements that are unsafe to display as text,
+// such as script, style, iframe and object. Comments are always removed,
+// text is always HTML escaped and any allowed elements that are left unclosed get closed.
+func Sanitize(s string, p Policy) string {
+	var (
+		b     strings.Builder
+		open  []string // open is the stack of allowed elements that are yet to be closed.
+		skip  string   // skip is the name of the unsafe element whose contents are being removed.
+		depth int      // depth is the nesting of the skip element.
+	)
+
+	b.Grow(len(s))
+
+	z := nethtml.NewTokenizer(strings.NewReader(s))
+
+	for {
+		tt := z.Next()
+		if tt == nethtml.ErrorToken {
+			break
+		}
+
+		if skip != "" {
+			name, _ := z.TagName()
+
+			switch {
+			case tt == nethtml.StartTagToken && string(name) == skip:
+				depth++
+			case tt == nethtml.EndTagToken && string(name) == skip:
+				depth--
+			}
+
+			if depth == 0 {
+				skip = ""
+			}
+
+			continue
+		}
+
+		switch tt {
+		case nethtml.TextToken:
+			b.WriteString(html.EscapeString(string(z.Text())))
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			name, attr := z.TagName()
+			tag := string(name)
+
+			allowed, ok := p.Elements[tag]
+			if !ok {
+				// the tokenizer treats the contents of a raw text element as text, even after a self-closing tag
+				if unsafe[tag] && (tt == nethtml.StartTagToken || raws[tag]) {
+					skip, depth = tag, 1
+				}
+
+				continue
+			}
+
+			b.WriteString(p.tag(z, tag, allowed, attr))
+
+			switch {
+			case voids[tag]:
+				if tt == nethtml.SelfClosingTagToken {
+					b.WriteString(" />")
+
+					continue
+				}
+
+				b.WriteString(">")
+			default:
+				b.WriteString(">")
+
+				if tt == nethtml.SelfClosingTagToken {
+					b.WriteString("</" + tag + ">")
+
+					continue
+				}
+
+				open = append(open, tag)
+			}
+		case nethtml.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != tag {
+					continue
+				}
+				// close the element and any unclosed elements within it
+				for j := len(open) - 1; j >= i; j-- {
+					b.WriteString("</" + open[j] + ">")
+				}
+
+				open = open[:i]
+
+				break
+			}
+		case nethtml.CommentToken, nethtml.DoctypeToken, nethtml.ErrorToken:
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i] + ">")
+	}
+
+	return b.String()
+}
+
+// tag returns the opening of the HTML element tag with only the allowed attributes, but without the closing ">".
+func (p Policy) tag(z *nethtml.Tokenizer, name string, allowed []string, attr bool) string {
+	var b strings.Builder
+
+	b.WriteString("<" + name)
+
+	link := false
+
+	for attr {
+		var k, v []byte
+
+		k, v, attr = z.TagAttr()
+		key, val := string(k), string(v)
+
+		if !contains(allowed, key) {
+			continue
+		}
+
+		if urls[key] && !p.allowURL(val) {
+			continue
+		}
+
+		if name == "a" && key == "href" {
+			link = true
+		}
+
+		if key == "rel" && p.Rel != "" {
+			continue
+		}
+
+		b.WriteString(" " + key + `="` + html.EscapeString(val) + `"`)
+	}
+
+	if link && p.Rel != "" {
+		b.WriteString(` rel="` + html.EscapeString(p.Rel) + `"`)
+	}
+
+	return b.String()
+}
+
+// allowURL reports whether the URL is relative or uses one of the policy schemes.
+func (p Policy) allowURL(s string) bool {
+	// browsers ignore whitespace and control characters within a scheme, such as "java\tscript:"
+	s = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '\u007f' {
+			return -1
+		}
+
+		return r
+	}, s)
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+
+	if u.Scheme == "" {
+		if strings.HasPrefix(s, "//") {
+			// a protocol-relative URL uses the http or https scheme of the page
+			return contains(p.Schemes, "http") || contains(p.Schemes, "https")
+		}
+
+		return true
+	}
+
+	for _, scheme := range p.Schemes {
+		if strings.EqualFold(scheme, u.Scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unsafe are the HTML elements whose contents are removed along with the element.
+var unsafe = map[string]bool{
+	"applet": true, "frameset": true, "iframe": true, "math": true, "noembed": true,
+	"noframes": true, "noscript": true, "object": true, "script": true, "style": true,
+	"svg": true, "template": true, "title": true, "xmp": true,
+}
+
+// raws are the HTML elements that contain raw text.
+var raws = map[string]bool{
+	"iframe": true, "noembed": true, "noframes": true, "noscript": true, "plaintext": true,
+	"script": true, "style": true, "textarea": true, "title": true, "xmp": true,
+}
+
+// urls are the HTML attributes that contain a URL.
+var urls = map[string]bool{
+	"action": true, "background": true, "cite": true, "formaction": true,
+	"href": true, "poster": true, "src": true,
+}
+
+// voids are the HTML elements that cannot have any contents or a closing tag.
+var voids = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
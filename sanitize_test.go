@@ -0,0 +1,89 @@
+package cfw_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleSanitize() {
+	const s = `<p onclick="steal()">Hello <b>world</b>, <a href="javascript:alert(1)">click</a> ` +
+		`or <a href="https://go.dev" rel="me">visit</a>.<script>alert(2)</script></p>`
+	fmt.Println(cfw.Sanitize(s, cfw.WheelsPolicy()))
+	// Output: <p>Hello <b>world</b>, <a>click</a> or <a href="https://go.dev" rel="nofollow">visit</a>.</p>
+}
+
+func ExamplePolicy() {
+	p := cfw.Policy{
+		Elements: map[string][]string{"img": {"src", "alt"}},
+		Schemes:  []string{"https"},
+	}
+	fmt.Println(cfw.Sanitize(`<div><img src="https://example.com/a.png" alt="A" width="9"></div>`, p))
+	fmt.Println(cfw.Sanitize(`<img src="http://example.com/a.png" alt="B"/>`, p))
+	// Output: <img src="https://example.com/a.png" alt="A">
+	// <img alt="B" />
+}
+
+func TestSanitize(t *testing.T) {
+	t.Parallel()
+
+	wheels := cfw.WheelsPolicy()
+	none := cfw.Policy{}
+	rel := cfw.Policy{Elements: map[string][]string{"a": {"href", "rel"}}, Schemes: []string{"https"}}
+
+	tests := []struct {
+		name string
+		s    string
+		p    cfw.Policy
+		want string
+	}{
+		{"empty", "", wheels, ""},
+		{"text", "Fish & chips < 5", wheels, "Fish &amp; chips &lt; 5"},
+		{"entities", "&lt;b&gt; &amp;", wheels, "&lt;b&gt; &amp;"},
+		{"no policy", `<p>Hello <b>world</b></p>`, none, "Hello world"},
+		{"allowed", `<p>Hello <STRONG>world</STRONG><br/>again</p>`, wheels, "<p>Hello <strong>world</strong><br />again</p>"},
+		{"attributes", `<p class="x" style="color:red">a</p><span class="hl" id="y">b</span>`, wheels, `<p>a</p><span class="hl">b</span>`},
+		{"quotes", `<a href="/?a=1&amp;b=&quot;2&quot;">q</a>`, wheels, `<a href="/?a=1&amp;b=&#34;2&#34;" rel="nofollow">q</a>`},
+		{"relative", `<a href="/path">p</a>`, wheels, `<a href="/path" rel="nofollow">p</a>`},
+		{"mailto", `<a href="mailto:a@example.com">m</a>`, wheels, `<a href="mailto:a@example.com" rel="nofollow">m</a>`},
+		{"javascript", `<a href="JavaScript:alert(1)">x</a>`, wheels, `<a>x</a>`},
+		{"obscured", "<a href=\"java\tscript:alert(1)\">x</a>", wheels, `<a>x</a>`},
+		{"entity scheme", `<a href="javascript&#58;alert(1)">x</a>`, wheels, `<a>x</a>`},
+		{"data", `<a href="data:text/html,<b>x</b>">x</a>`, wheels, `<a>x</a>`},
+		{"protocol relative", `<a href="//example.com">x</a>`, wheels, `<a href="//example.com" rel="nofollow">x</a>`},
+		{"keep rel", `<a href="https://go.dev" rel="author">x</a>`, rel, `<a href="https://go.dev" rel="author">x</a>`},
+		{"script", `a<script>alert("<b>")</script>b`, wheels, "ab"},
+		{"self-closing script", `a<script/>alert(1)`, wheels, "a"},
+		{"style", `<style>p{}</style><p>a</p>`, wheels, "<p>a</p>"},
+		{"nested object", `<object><object>x</object>y</object>z`, wheels, "z"},
+		{"svg", `<svg><script>alert(1)</script></svg>ok`, wheels, "ok"},
+		{"comment", `a<!-- <b>x</b> -->b`, wheels, "ab"},
+		{"event", `<b onmouseover="alert(1)">x</b>`, wheels, "<b>x</b>"},
+		{"unclosed", `<p><b>bold<i>italic`, wheels, "<p><b>bold<i>italic</i></b></p>"},
+		{"misnested", `<b><i>x</b>y</i>`, wheels, "<b><i>x</i></b>y"},
+		{"stray end", `x</b></p>`, wheels, "x"},
+		{"emoji", `<b>brown 🦊</b>`, wheels, "<b>brown 🦊</b>"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.Sanitize(tt.s, tt.p); got != tt.want {
+				t.Errorf("Sanitize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWheelsPolicy(t *testing.T) {
+	t.Parallel()
+
+	s := cfw.SimpleFormat("Visit https://go.dev\n\nbye", true, true)
+	s = cfw.AutoLink(s, cfw.LinkAll)
+
+	const want = `<p>Visit <a href="https://go.dev" rel="nofollow">https://go.dev</a></p>` + "\n\n<p>bye</p>"
+	if got := cfw.Sanitize(s, cfw.WheelsPolicy()); got != want {
+		t.Errorf("Sanitize() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,39 @@
+package strict_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/bengarrett/cfw/strict"
+)
+
+func FuzzObfuscate(f *testing.F) {
+	f.Add(int64(1))
+	f.Add(int64(999999999999))
+	f.Add(int64(-1))
+	f.Fuzz(func(t *testing.T, i int64) {
+		s := strconv.FormatInt(i, 10)
+
+		o, err := strict.Obfuscate(s)
+		if err != nil {
+			if i > 0 && len(s) <= 18 {
+				t.Errorf("Obfuscate(%q) error = %v", s, err)
+			}
+
+			return
+		}
+
+		if d, err := strict.DeObfuscate(o); err != nil || d != s {
+			t.Errorf("DeObfuscate(Obfuscate(%q)) = %q, %v, want %q", s, d, err, s)
+		}
+	})
+}
+
+func FuzzTruncate(f *testing.F) {
+	f.Add("this is a test to see if this works or not.", "[more]", 20)
+	f.Fuzz(func(t *testing.T, s, replace string, n int) {
+		if _, err := strict.Truncate(s, replace, n); err == nil && n < 0 {
+			t.Errorf("Truncate(%q, %q, %d) is missing an error", s, replace, n)
+		}
+	})
+}
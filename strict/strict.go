@@ -0,0 +1,136 @@
+// Package strict contains error returning variants of the cfw helpers that validate their input.
+// Where the cfw helpers return the original or an empty string on bad input
+// to match CFWheels, these variants return an error that can be tested using errors.Is.
+// The helpers that always succeed, such as cfw.Humanize and cfw.StripTags, have no strict variant.
+// © Ben Garrett https://github.com/bengarrett/cfw
+package strict
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bengarrett/cfw"
+)
+
+const ellipsis = "..."
+
+var (
+	// ErrPhraseNotFound is returned when the excerpt phrase is not found in the text.
+	ErrPhraseNotFound = errors.New("phrase not found")
+	// ErrLimitTooSmall is returned when a length limit or radius is negative,
+	// or is too small to fit the replacement text.
+	ErrLimitTooSmall = errors.New("limit too small")
+	// ErrInvalidObfuscation is returned when a value is not an obfuscated string,
+	// or is not a positive integer that can be obfuscated.
+	ErrInvalidObfuscation = errors.New("invalid obfuscation")
+)
+
+// DeObfuscate the obfuscated string created by Obfuscate.
+// An error is returned if s is not an obfuscated string, including when s is a plain integer.
+func DeObfuscate(s string) (string, error) {
+	v := cfw.DeObfuscate(s)
+	if v == s || !strings.EqualFold(cfw.Obfuscate(v), s) {
+		return "", fmt.Errorf("deobfuscate %q: %w", s, ErrInvalidObfuscation)
+	}
+
+	return v, nil
+}
+
+// Obfuscate a numeric string to insecurely hide database primary key values when passed along a URL.
+// An error is returned if s is not a positive integer without leading zeros, or if it has more than 18 digits.
+func Obfuscate(s string) (string, error) {
+	if o := cfw.Obfuscate(s); o != s {
+		return o, nil
+	}
+
+	return "", fmt.Errorf("obfuscate %q: %w", s, ErrInvalidObfuscation)
+}
+
+// Excerpt extracts an excerpt from s of the phrase along with a radius of n characters before and after it.
+// An error is returned if the phrase is not found in s or if the radius is negative,
+// otherwise the result is the same as cfw.Excerpt.
+func Excerpt(s, replace, phrase string, n int) (string, error) {
+	if n < 0 {
+		return "", fmt.Errorf("excerpt radius %d: %w", n, ErrLimitTooSmall)
+	}
+
+	e := cfw.Excerpt(s, replace, phrase, n)
+	if e == "" {
+		return "", fmt.Errorf("excerpt %q: %w", phrase, ErrPhraseNotFound)
+	}
+
+	return e, nil
+}
+
+// ExcerptWith is the same as Excerpt, except the result is the same as cfw.ExcerptWith using opts.
+func ExcerptWith(s, phrase string, n int, opts cfw.ExcerptOptions) (string, error) {
+	if n < 0 {
+		return "", fmt.Errorf("excerpt radius %d: %w", n, ErrLimitTooSmall)
+	}
+
+	e := cfw.ExcerptWith(s, phrase, n, opts)
+	if e == "" {
+		return "", fmt.Errorf("excerpt %q: %w", phrase, ErrPhraseNotFound)
+	}
+
+	return e, nil
+}
+
+// Truncate a string to the specified number of characters and replace the trailing characters.
+// An error is returned if n is shorter than the replacement text, even when s is not truncated,
+// otherwise the result is the same as cfw.Truncate.
+func Truncate(s, replace string, n int) (string, error) {
+	if replace == "" {
+		replace = ellipsis
+	}
+
+	if n < utf8.RuneCountInString(replace) {
+		return "", fmt.Errorf("truncate %d is shorter than the replacement %q: %w", n, replace, ErrLimitTooSmall)
+	}
+
+	return cfw.Truncate(s, replace, n), nil
+}
+
+// TruncateWith is the same as Truncate, except the result is the same as cfw.TruncateWith using opts.
+// When the Exclusive option is used, an error is only returned if n is negative.
+func TruncateWith(s string, n int, opts cfw.TruncateOptions) (string, error) {
+	replace := opts.Replace
+	if replace == "" {
+		replace = ellipsis
+	}
+
+	size := 0
+	if !opts.Exclusive {
+		size = utf8.RuneCountInString(replace)
+		if opts.Unit == cfw.Bytes {
+			size = len(replace)
+		}
+	}
+
+	if n < size {
+		return "", fmt.Errorf("truncate %d is shorter than the replacement %q: %w", n, replace, ErrLimitTooSmall)
+	}
+
+	return cfw.TruncateWith(s, n, opts), nil
+}
+
+// WordTruncate truncates a string to the specified number of words and replaces the trailing characters.
+// An error is returned if n is negative, otherwise the result is the same as cfw.WordTruncate.
+func WordTruncate(s, replace string, n int) (string, error) {
+	if n < 0 {
+		return "", fmt.Errorf("word truncate %d: %w", n, ErrLimitTooSmall)
+	}
+
+	return cfw.WordTruncate(s, replace, n), nil
+}
+
+// WordTruncateWith is the same as WordTruncate, except the result is the same as cfw.WordTruncateWith using opts.
+func WordTruncateWith(s string, n int, opts cfw.TruncateOptions) (string, error) {
+	if n < 0 {
+		return "", fmt.Errorf("word truncate %d: %w", n, ErrLimitTooSmall)
+	}
+
+	return cfw.WordTruncateWith(s, n, opts), nil
+}
@@ -0,0 +1,236 @@
+package strict_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/cfw"
+	"github.com/bengarrett/cfw/strict"
+)
+
+func ExampleTruncate() {
+	_, err := strict.Truncate("Go is an open source programming language", "[more]", 4)
+	fmt.Println(errors.Is(err, strict.ErrLimitTooSmall))
+	fmt.Println(err)
+	// Output: true
+	// truncate 4 is shorter than the replacement "[more]": limit too small
+}
+
+func ExampleDeObfuscate() {
+	s, _ := strict.DeObfuscate("b226582")
+	fmt.Println(s)
+	_, err := strict.DeObfuscate("15765")
+	fmt.Println(err)
+	// Output: 15765
+	// deobfuscate "15765": invalid obfuscation
+}
+
+func TestDeObfuscate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr error
+	}{
+		{"ok", "eb77359232", "999999999", nil},
+		{"uppercase", "EB77359232", "999999999", nil},
+		{"integer", "999999999", "", strict.ErrInvalidObfuscation},
+		{"empty", "", "", strict.ErrInvalidObfuscation},
+		{"text", "hello world", "", strict.ErrInvalidObfuscation},
+		{"checksum", "9c1c6", "", strict.ErrInvalidObfuscation},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := strict.DeObfuscate(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeObfuscate() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DeObfuscate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObfuscate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr error
+	}{
+		{"ok", "999999999", "eb77359232", nil},
+		{"one", "1", "9b1c6", nil},
+		{"zero", "0", "", strict.ErrInvalidObfuscation},
+		{"leading zero", "0123", "", strict.ErrInvalidObfuscation},
+		{"negative", "-15", "", strict.ErrInvalidObfuscation},
+		{"sign", "+15", "", strict.ErrInvalidObfuscation},
+		{"text", "<a>", "", strict.ErrInvalidObfuscation},
+		{"too long", "1234567890123456789", "", strict.ErrInvalidObfuscation},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := strict.Obfuscate(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Obfuscate() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Obfuscate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	t.Parallel()
+
+	const s = "CFWheels: testing the excerpt view helper to see if it works or not."
+
+	tests := []struct {
+		name    string
+		phrase  string
+		n       int
+		want    string
+		wantErr error
+	}{
+		{"ok", "excerpt view helper", 10, "[more]sting the excerpt view helper to see if[more]", nil},
+		{"missing", "jklsduiermobk", 25, "", strict.ErrPhraseNotFound},
+		{"negative", "excerpt view helper", -1, "", strict.ErrLimitTooSmall},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := strict.Excerpt(s, "[more]", tt.phrase, tt.n)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Excerpt() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Excerpt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLegacy checks that the strict helpers return the same text as the cfw helpers for valid input.
+func TestLegacy(t *testing.T) {
+	t.Parallel()
+
+	const s = "The quick brown 🦊 jumps over the lazy 🐶 and keeps on running."
+
+	tests := []struct {
+		name    string
+		replace string
+		phrase  string
+		n       int
+	}{
+		{"zero", "", "🦊", 0},
+		{"short", "", "lazy", 3},
+		{"replace", "[more]", "jumps", 6},
+		{"emoji", "💬", "🐶", 17},
+		{"long", "", "The", 20},
+		{"whole", "[more]", "running.", 100},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got, err := strict.Excerpt(s, tt.replace, tt.phrase, tt.n); err != nil ||
+				got != cfw.Excerpt(s, tt.replace, tt.phrase, tt.n) {
+				t.Errorf("Excerpt() = %q, %v, want %q", got, err, cfw.Excerpt(s, tt.replace, tt.phrase, tt.n))
+			}
+			if got, err := strict.WordTruncate(s, tt.replace, tt.n); err != nil ||
+				got != cfw.WordTruncate(s, tt.replace, tt.n) {
+				t.Errorf("WordTruncate() = %q, %v, want %q", got, err, cfw.WordTruncate(s, tt.replace, tt.n))
+			}
+			got, err := strict.Truncate(s, tt.replace, tt.n)
+			if errors.Is(err, strict.ErrLimitTooSmall) {
+				return
+			}
+			if want := cfw.Truncate(s, tt.replace, tt.n); err != nil || got != want {
+				t.Errorf("Truncate() = %q, %v, want %q", got, err, want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	const s = "this is a test to see if this works or not."
+
+	type args struct {
+		s    string
+		n    int
+		opts cfw.TruncateOptions
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{"ok", args{s, 20, cfw.TruncateOptions{Replace: "[more]"}}, "this is a test[more]", nil},
+		{"replacement", args{s, 6, cfw.TruncateOptions{Replace: "[more]"}}, "[more]", nil},
+		{"too small", args{s, 5, cfw.TruncateOptions{Replace: "[more]"}}, "", strict.ErrLimitTooSmall},
+		{"too small short text", args{"test", 5, cfw.TruncateOptions{Replace: "[more]"}}, "", strict.ErrLimitTooSmall},
+		{"default", args{s, 2, cfw.TruncateOptions{}}, "", strict.ErrLimitTooSmall},
+		{"bytes", args{s, 5, cfw.TruncateOptions{Replace: "💬", Unit: cfw.Bytes}}, "t💬", nil},
+		{"bytes too small", args{s, 3, cfw.TruncateOptions{Replace: "💬", Unit: cfw.Bytes}}, "", strict.ErrLimitTooSmall},
+		{"exclusive", args{s, 0, cfw.TruncateOptions{Exclusive: true}}, "...", nil},
+		{"negative", args{s, -1, cfw.TruncateOptions{Exclusive: true}}, "", strict.ErrLimitTooSmall},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := strict.TruncateWith(tt.args.s, tt.args.n, tt.args.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TruncateWith() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TruncateWith() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordTruncate(t *testing.T) {
+	t.Parallel()
+
+	const s = "CFWheels is a framework for ColdFusion"
+
+	tests := []struct {
+		name    string
+		n       int
+		want    string
+		wantErr error
+	}{
+		{"ok", 4, "CFWheels is a framework...", nil},
+		{"zero", 0, "CFWheels...", nil},
+		{"negative", -1, "", strict.ErrLimitTooSmall},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := strict.WordTruncate(s, "", tt.n)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("WordTruncate() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("WordTruncate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package cfw
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// StripOptions changes the text returned by StripTagsWith.
+type StripOptions struct {
+	// Decode HTML entities such as &amp; and &lt; into the characters they represent.
+	Decode bool
+	// Inline does not replace block elements such as paragraphs and line breaks with whitespace,
+	// so the words either side of them may run together as they do with CFWheels.
+	Inline bool
+}
+
+// StripTagsWith removes all HTML tags, comments and the contents of script and style elements from s.
+// Unlike StripTags the HTML entities and block element whitespace handling can be configured using opts.
+func StripTagsWith(s string, opts StripOptions) string {
+	return collect(newStripper(strings.NewReader(s), opts), len(s))
+}
+
+// StripElements removes the named HTML elements from s, while keeping all other markup.
+// If keep is true, the text and any other elements within the named elements are kept,
+// otherwise the named elements are removed along with all their contents,
+// where the contents of an element without a closing tag end with its parent element.
+// For example, StripElements(s, true, "a") is the same as StripLinks(s),
+// while StripElements(s, false, "img", "iframe") removes all images and inline frames.
+func StripElements(s string, keep bool, names ...string) string {
+	return collect(newElementStripper(strings.NewReader(s), keep, names...), len(s))
+}
+
+// NewStripTagsReader returns a reader that removes all HTML tags from r, the same as StripTags.
+// The HTML is processed one token at a time, so the memory used is bounded by the largest tag or text
+// between tags rather than the size of the document.
+func NewStripTagsReader(r io.Reader) io.Reader {
+	return NewStripTagsReaderWith(r, StripOptions{})
+}
+
+// NewStripTagsReaderWith returns a reader that removes all HTML tags from r, the same as StripTagsWith.
+func NewStripTagsReaderWith(r io.Reader, opts StripOptions) io.Reader {
+	return &tokenReader{t: newStripper(r, opts)}
+}
+
+// NewStripLinksReader returns a reader that removes all HTML links from r, the same as StripLinks.
+// The HTML is processed one token at a time, so the memory used is bounded by the largest tag or text
+// between tags rather than the size of the document.
+func NewStripLinksReader(r io.Reader) io.Reader {
+	return &tokenReader{t: newElementStripper(r, true, "a")}
+}
+
+// tokenReader reads the HTML returned by tokens.
+type tokenReader struct {
+	t   tokens
+	buf []byte // buf is the unread HTML of the last token.
+	err error
+}
+
+func (r *tokenReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		r.buf, r.err = r.t.next()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
+
+// tokens returns the HTML of a document one token at a time.
+type tokens interface {
+	// next returns the HTML of the next token, which is only valid until the following call.
+	// At the end of the document, next returns the tokenizer error which is usually io.EOF.
+	next() ([]byte, error)
+}
+
+// collect returns all the HTML from t, where size is the expected length.
+func collect(t tokens, size int) string {
+	var b strings.Builder
+
+	b.Grow(size)
+
+	for {
+		p, err := t.next()
+		if err != nil {
+			break
+		}
+
+		b.Write(p)
+	}
+
+	return b.String()
+}
+
+// blocks are the HTML elements that are replaced by whitespace when stripped.
+var blocks = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true,
+	"caption": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"fieldset": true, "figcaption": true, "figure": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "li": true, "main": true, "nav": true,
+	"ol": true, "p": true, "pre": true, "section": true, "table": true,
+	"tbody": true, "td": true, "tfoot": true, "th": true, "thead": true,
+	"tr": true, "ul": true,
+}
+
+// optionalEnds are the HTML elements with an optional closing tag,
+// which are closed by the start of the next element of the same name.
+var optionalEnds = map[string]bool{
+	"colgroup": true, "dd": true, "dt": true, "li": true, "optgroup": true, "option": true,
+	"p": true, "rp": true, "rt": true, "tbody": true, "td": true, "tfoot": true,
+	"th": true, "thead": true, "tr": true,
+}
+
+// stripper removes HTML tags from a tokenized document, one token at a time.
+type stripper struct {
+	z     *html.Tokenizer
+	opts  StripOptions
+	raw   string // raw is the name of the element containing the next text token.
+	space bool   // space is pending whitespace to write before the next text.
+	text  bool   // text is true once any non-whitespace text has been written.
+	blank bool   // blank is true when the last byte written was whitespace.
+	buf   []byte
+}
+
+func newStripper(r io.Reader, opts StripOptions) *stripper {
+	z := html.NewTokenizer(r)
+	z.AllowCDATA(true)
+
+	return &stripper{z: z, opts: opts}
+}
+
+// next returns the text of the next token with its markup removed.
+func (st *stripper) next() ([]byte, error) {
+	z := st.z
+	tt := z.Next()
+
+	if tt != html.TextToken {
+		st.raw = ""
+	}
+
+	switch tt {
+	case html.ErrorToken:
+		return nil, z.Err()
+	case html.TextToken:
+		return st.textToken(), nil
+	case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+		name, _ := z.TagName()
+		tag := string(name)
+
+		if tt != html.EndTagToken {
+			st.raw = tag
+		}
+
+		if blocks[tag] && !st.opts.Inline {
+			st.space = true
+		}
+	case html.CommentToken, html.DoctypeToken:
+	}
+
+	return nil, nil
+}
+
+func (st *stripper) textToken() []byte {
+	raw := st.raw
+	st.raw = ""
+
+	var p []byte
+
+	switch {
+	case raw == "script", raw == "style":
+		// drop the code
+		return nil
+	case raws[raw]:
+		// the tokenizer returns any markup within these elements as text
+		p = []byte(StripTagsWith(string(st.z.Raw()), st.opts))
+	default:
+		if bytes.HasPrefix(st.z.Raw(), []byte("<![CDATA[")) {
+			return nil
+		}
+
+		if st.opts.Decode {
+			p = st.z.Text()
+		} else {
+			p = st.z.Raw()
+		}
+	}
+
+	if len(p) == 0 {
+		return nil
+	}
+
+	st.buf = st.buf[:0]
+	if st.space && st.text && !st.blank && !isSpace(p[0]) {
+		st.buf = append(st.buf, ' ')
+	}
+
+	st.buf = append(st.buf, p...)
+	st.space = false
+	st.blank = isSpace(p[len(p)-1])
+
+	if !st.text && strings.TrimSpace(string(p)) != "" {
+		st.text = true
+	}
+
+	return st.buf
+}
+
+// elementStripper removes the named HTML elements from a tokenized document, one token at a time.
+type elementStripper struct {
+	z     *html.Tokenizer
+	names map[string]bool
+	keep  bool     // keep the text and any other elements within the named elements.
+	open  []string // open is the stack of kept elements that are yet to be closed.
+	skip  []string // skip is the stack of the removed element and the elements within it that are yet to be closed.
+	buf   []byte
+}
+
+func newElementStripper(r io.Reader, keep bool, names ...string) *elementStripper {
+	st := elementStripper{z: html.NewTokenizer(r), names: make(map[string]bool, len(names)), keep: keep}
+	for _, name := range names {
+		st.names[strings.ToLower(name)] = true
+	}
+
+	return &st
+}
+
+// next returns the next token unless it is, or is within, one of the named elements.
+func (st *elementStripper) next() ([]byte, error) {
+	z := st.z
+	tt := z.Next()
+
+	switch tt {
+	case html.ErrorToken:
+		return nil, z.Err()
+	case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+		// TagName lowercases the raw tag, so it must be copied first
+		st.buf = append(st.buf[:0], z.Raw()...)
+		name, _ := z.TagName()
+		tag := string(name)
+
+		if len(st.skip) > 0 {
+			if st.skipTag(tt, tag) {
+				return st.buf, nil
+			}
+
+			return nil, nil
+		}
+
+		if !st.names[tag] {
+			if !st.keep {
+				st.openTag(tt, tag)
+			}
+
+			return st.buf, nil
+		}
+
+		if !st.keep && tt != html.EndTagToken && !voids[tag] {
+			// the tokenizer treats the contents of a raw text element as text, even after a self-closing tag
+			if tt == html.StartTagToken || raws[tag] {
+				st.skip = append(st.skip[:0], tag)
+			}
+		}
+
+		return nil, nil
+	case html.TextToken, html.CommentToken, html.DoctypeToken:
+	}
+
+	if len(st.skip) > 0 {
+		return nil, nil
+	}
+
+	return z.Raw(), nil
+}
+
+// openTag tracks the kept elements that are yet to be closed, the same as walkHTML.
+func (st *elementStripper) openTag(tt html.TokenType, tag string) {
+	switch {
+	case tt == html.StartTagToken && !voids[tag]:
+		st.open = append(st.open, tag)
+	case tt == html.EndTagToken:
+		for i := len(st.open) - 1; i >= 0; i-- {
+			if st.open[i] == tag {
+				st.open = st.open[:i]
+
+				break
+			}
+		}
+	}
+}
+
+// skipTag tracks the elements within the removed element, and reports whether the tag ends the removal
+// and is kept, which is the end tag of an element that was open before the removed element.
+// So an unclosed element, such as a p without a closing tag, is removed up to the end of its parent.
+func (st *elementStripper) skipTag(tt html.TokenType, tag string) bool {
+	switch tt {
+	case html.StartTagToken:
+		if voids[tag] {
+			return false
+		}
+
+		// a p element cannot contain another, so the start of a p also ends the removed p
+		if tag == st.skip[0] && (tag == "p" || len(st.skip) == 1 && optionalEnds[tag]) {
+			st.skip = st.skip[:1]
+
+			return false
+		}
+
+		st.skip = append(st.skip, tag)
+	case html.EndTagToken:
+		for i := len(st.skip) - 1; i >= 0; i-- {
+			if st.skip[i] == tag {
+				st.skip = st.skip[:i]
+
+				return false
+			}
+		}
+
+		for i := len(st.open) - 1; i >= 0; i-- {
+			if st.open[i] == tag {
+				st.open, st.skip = st.open[:i], st.skip[:0]
+
+				return true
+			}
+		}
+	case html.SelfClosingTagToken, html.ErrorToken, html.TextToken, html.CommentToken, html.DoctypeToken:
+	}
+
+	return false
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\f':
+		return true
+	}
+
+	return false
+}
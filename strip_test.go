@@ -0,0 +1,222 @@
+package cfw_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleStripTagsWith() {
+	const s = `<p>Fish &amp; chips</p><p>Salt &amp; vinegar</p>`
+	fmt.Println(cfw.StripTagsWith(s, cfw.StripOptions{}))
+	fmt.Println(cfw.StripTagsWith(s, cfw.StripOptions{Decode: true}))
+	fmt.Println(cfw.StripTagsWith(s, cfw.StripOptions{Inline: true}))
+	// Output: Fish &amp; chips Salt &amp; vinegar
+	// Fish & chips Salt & vinegar
+	// Fish &amp; chipsSalt &amp; vinegar
+}
+
+func TestStripTagsWith(t *testing.T) {
+	t.Parallel()
+
+	// values:
+	// https://github.com/cfwheels/cfwheels/blob/1c3b9d6db79cdfbfbe49ae6816f6dc96262ccf82
+	// /wheels/tests/view/text/excerpt.cfc
+	str := `<h1>this</h1><p><a href="http://www.google.com" title="google">is</a></p><p>a ` +
+		`<a href="mailto:someone@example.com" title="invalid email">test</a> to<br>` +
+		`<a name="anchortag">see</a> if this works or not.</p>`
+	tests := []struct {
+		name string
+		s    string
+		opts cfw.StripOptions
+		want string
+	}{
+		{"empty", "", cfw.StripOptions{}, ""},
+		{"cfwheels", str, cfw.StripOptions{Inline: true}, "thisisa test tosee if this works or not."},
+		{"blocks", str, cfw.StripOptions{}, "this is a test to see if this works or not."},
+		{"decode", `<b>&lt;b&gt;</b> &quot;&#39;&nbsp;&eacute;`, cfw.StripOptions{Decode: true}, "<b> \"' é"},
+		{"raw", `<b>&lt;b&gt;</b>`, cfw.StripOptions{}, "&lt;b&gt;"},
+		{"title", `<title>A &amp; B</title>`, cfw.StripOptions{Decode: true}, "A & B"},
+		{"script", `<SCRIPT type="text/javascript">document.write("<p>")</SCRIPT>ok`, cfw.StripOptions{}, "ok"},
+		{"whitespace", "<p>a</p>\n<p>b</p><br/><br/>c", cfw.StripOptions{}, "a\nb c"},
+		{"leading", "<p><p>a</p>", cfw.StripOptions{}, "a"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.StripTagsWith(tt.s, tt.opts); got != tt.want {
+				t.Errorf("StripTagsWith() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleStripElements() {
+	const s = `<p>A <b>cat</b> <img src="cat.jpg"> and a <iframe src="/video">video</iframe>.</p>`
+	fmt.Println(cfw.StripElements(s, false, "img", "iframe"))
+	fmt.Println(cfw.StripElements(s, true, "p", "b"))
+	// Output: <p>A <b>cat</b>  and a .</p>
+	// A cat <img src="cat.jpg"> and a <iframe src="/video">video</iframe>.
+}
+
+func TestStripElements(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		s     string
+		keep  bool
+		names []string
+	}
+
+	const nested = `<div>a<div>b</div>c</div>d`
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"empty", args{"", false, nil}, ""},
+		{"no names", args{`<b>x</b>`, false, nil}, `<b>x</b>`},
+		{"links", args{`<a href="/">x</a> <b>y</b>`, true, []string{"a"}}, `x <b>y</b>`},
+		{"case", args{`<IMG SRC="a.png"><B>x</B>`, true, []string{"Img"}}, `<B>x</B>`},
+		{"keep text", args{`<span class="x">a <i>b</i></span>`, true, []string{"span"}}, `a <i>b</i>`},
+		{"subtree", args{`<span class="x">a <i>b</i></span>c`, false, []string{"span"}}, `c`},
+		{"nested", args{nested, false, []string{"div"}}, `d`},
+		{"nested keep", args{nested, true, []string{"div"}}, `abcd`},
+		{"void", args{`a<img src="x">b<br/>c`, false, []string{"img", "br"}}, `abc`},
+		{"raw", args{`a<iframe><p>x</p></iframe>b`, false, []string{"iframe"}}, `ab`},
+		{"script", args{`a<script>if (a<b) {}</script>b`, false, []string{"script"}}, `ab`},
+		{"self-closing", args{`<div/>a`, false, []string{"div"}}, `a`},
+		{"comment", args{`<!-- <img> --><img>`, false, []string{"img"}}, `<!-- <img> -->`},
+		{"emoji", args{`<b>🦊</b><i>🐕</i>`, false, []string{"b"}}, `<i>🐕</i>`},
+		{"unclosed", args{`<div>a<p>b</div>c`, false, []string{"p"}}, `<div>a</div>c`},
+		{"unclosed nested", args{`<div><span><p>a<i>b</span>c</div>d`, false, []string{"p"}}, `<div><span></span>c</div>d`},
+		{"unclosed end", args{`a<p>b`, false, []string{"p"}}, `a`},
+		{"siblings", args{`<p>a<p>b<i>c<p>d</p>e`, false, []string{"p"}}, `e`},
+		{"list", args{`<ul><li>a<li>b<ul><li>c</ul></ul>d`, false, []string{"li"}}, `<ul></ul>d`},
+		{"inner unclosed", args{`<a href="/"><b>x</a>y`, false, []string{"a"}}, `y`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.StripElements(tt.args.s, tt.args.keep, tt.args.names...); got != tt.want {
+				t.Errorf("StripElements() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleNewStripTagsReader() {
+	r := cfw.NewStripTagsReader(strings.NewReader(`<h1>Hello</h1><p>world!</p>`))
+	if _, err := io.Copy(os.Stdout, r); err != nil {
+		log.Fatal(err)
+	}
+	// Output: Hello world!
+}
+
+func TestNewStripTagsReader(t *testing.T) {
+	t.Parallel()
+
+	const page = `<html><head><title>Fish &amp; Chips</title><style>p { color: red; }</style></head>` +
+		`<body><!-- menu --><h1>Menu</h1><p>Cod &amp; <b>chips</b></p><script>alert("<p>")</script>` +
+		`<ul><li><a href="/order">Order</a></li><li>🐟</li></ul></body></html>`
+
+	tests := []struct {
+		name string
+		r    func(io.Reader) io.Reader
+	}{
+		{"reader", func(r io.Reader) io.Reader { return r }},
+		{"one byte", iotest.OneByteReader},
+		{"half", iotest.HalfReader},
+		{"data err", iotest.DataErrReader},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for _, opts := range []cfw.StripOptions{{}, {Decode: true}, {Inline: true}} {
+				r := cfw.NewStripTagsReaderWith(tt.r(strings.NewReader(page)), opts)
+				b, err := io.ReadAll(r)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got, want := string(b), cfw.StripTagsWith(page, opts); got != want {
+					t.Errorf("NewStripTagsReaderWith() = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewStripTagsReaderLarge(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chunk = `<div class="post"><p>Lorem <b>ipsum</b> dolor sit amet.</p></div>`
+		count = 50000
+	)
+
+	r := cfw.NewStripTagsReader(io.MultiReader(strings.NewReader("<html><body>"),
+		strings.NewReader(strings.Repeat(chunk, count)), strings.NewReader("</body></html>")))
+
+	n, err := io.Copy(io.Discard, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := int64(count*len("Lorem ipsum dolor sit amet.") + (count - 1))
+	if n != want {
+		t.Errorf("NewStripTagsReader() read %d bytes, want %d", n, want)
+	}
+}
+
+func TestNewStripTagsReaderError(t *testing.T) {
+	t.Parallel()
+
+	errRead := errors.New("read error")
+	r := cfw.NewStripTagsReader(io.MultiReader(strings.NewReader("<p>a</p><p>"), iotest.ErrReader(errRead)))
+
+	b, err := io.ReadAll(r)
+	if !errors.Is(err, errRead) {
+		t.Errorf("NewStripTagsReader() error = %v, want %v", err, errRead)
+	}
+
+	if string(b) != "a" {
+		t.Errorf("NewStripTagsReader() = %q, want %q", b, "a")
+	}
+}
+
+func ExampleNewStripLinksReader() {
+	r := cfw.NewStripLinksReader(strings.NewReader(`<p>Visit <a href="https://go.dev">Go</a>.</p>`))
+	if _, err := io.Copy(os.Stdout, r); err != nil {
+		log.Fatal(err)
+	}
+	// Output: <p>Visit Go.</p>
+}
+
+func TestNewStripLinksReader(t *testing.T) {
+	t.Parallel()
+
+	const page = `<P>Visit <A HREF="https://go.dev" title="a > b">Go</A>, <b>or</b>` +
+		"\n" + `<a name="top">🦊</a>.</P><!-- <a href="/">x</a> -->`
+
+	for _, fn := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader} {
+		b, err := io.ReadAll(cfw.NewStripLinksReader(fn(strings.NewReader(page))))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(b), cfw.StripLinks(page); got != want {
+			t.Errorf("NewStripLinksReader() = %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package cfw
+
+import (
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// FuncMap returns the cfw helpers for use with html/template, named after their CFWheels view helpers.
+//
+// The helpers that create markup, autoLink, paragraphFormat, sanitize, simpleFormat and stripLinks, return template.HTML.
+// To keep the template safe, these helpers HTML escape any string argument before adding their own markup,
+// while a template.HTML argument such as the result of another helper is used as is.
+// The stripLinks and sanitize helpers return markup that has been sanitized using the WheelsPolicy.
+//
+//	{{.Body | simpleFormat | autoLink}}
+//	{{truncate .Title 30}} {{truncate .Title 30 "[more]"}}
+//	{{timeAgoInWords .Created}} {{timeAgoInWords .Created true}}
+//	{{excerpt .Body "phrase" 100}}
+//	{{dateFormat .Created "mmm d, yyyy"}} {{timeFormat .Created "hh:mm tt"}}
+//	{{numberFormat .Total "9,999.99"}} {{dollarFormat .Price}}
+//	<a href="/user/{{obfuscateParam .ID}}">{{humanize .Name}}</a>
+func FuncMap() htmltemplate.FuncMap {
+	m := funcs()
+	m["autoLink"] = func(v interface{}, link ...string) htmltemplate.HTML {
+		return htmltemplate.HTML(AutoLink(escape(v), linkMode(link...)))
+	}
+	m["paragraphFormat"] = func(v interface{}) htmltemplate.HTML {
+		return htmltemplate.HTML(ParagraphFormat(escape(v)))
+	}
+	m["sanitize"] = func(v interface{}) htmltemplate.HTML {
+		return htmltemplate.HTML(Sanitize(markup(v), WheelsPolicy()))
+	}
+	m["simpleFormat"] = func(v interface{}, wrap ...bool) htmltemplate.HTML {
+		return htmltemplate.HTML(SimpleFormat(escape(v), optionalBool(wrap, true), false))
+	}
+	m["stripLinks"] = func(v interface{}) htmltemplate.HTML {
+		return htmltemplate.HTML(Sanitize(StripLinks(markup(v)), WheelsPolicy()))
+	}
+
+	return htmltemplate.FuncMap(m)
+}
+
+// TextFuncMap returns the cfw helpers for use with text/template, named after their CFWheels view helpers.
+// The helpers are the same as FuncMap, except that as text/template never escapes its output,
+// all helpers return a string and their arguments are never HTML escaped or sanitized.
+func TextFuncMap() texttemplate.FuncMap {
+	m := funcs()
+	m["autoLink"] = func(s string, link ...string) string {
+		return AutoLink(s, linkMode(link...))
+	}
+	m["paragraphFormat"] = ParagraphFormat
+	m["sanitize"] = func(s string) string {
+		return Sanitize(s, WheelsPolicy())
+	}
+	m["simpleFormat"] = func(s string, wrap ...bool) string {
+		return SimpleFormat(s, optionalBool(wrap, true), false)
+	}
+	m["stripLinks"] = StripLinks
+
+	return texttemplate.FuncMap(m)
+}
+
+// funcs returns the helpers that return plain text and are shared by both template packages.
+func funcs() map[string]interface{} {
+	return map[string]interface{}{
+		"dateFormat": func(t time.Time, mask ...string) string {
+			return DateFormat(t, optional(mask, ""))
+		},
+		"dateTimeFormat": func(t time.Time, mask ...string) string {
+			return DateTimeFormat(t, optional(mask, ""))
+		},
+		"deobfuscateParam": DeObfuscate,
+		"distanceOfTimeInWords": func(from, to time.Time, seconds ...bool) string {
+			return TimeDistance(from, to, optionalBool(seconds, false))
+		},
+		"dollarFormat": func(v interface{}) string {
+			return DollarFormat(float(v))
+		},
+		"excerpt": func(s, phrase string, radius int, replace ...string) string {
+			return Excerpt(s, optional(replace, ""), phrase, radius)
+		},
+		"humanize":  Humanize,
+		"hyphenize": Hyphenize,
+		"numberFormat": func(v interface{}, mask ...string) string {
+			return NumberFormat(float(v), optional(mask, ""))
+		},
+		"obfuscateParam": func(v interface{}) string {
+			return Obfuscate(fmt.Sprint(v))
+		},
+		"stripTags": StripTags,
+		"timeAgoInWords": func(from time.Time, seconds ...bool) string {
+			return TimeDistance(from, time.Now(), optionalBool(seconds, false))
+		},
+		"timeFormat": func(t time.Time, mask ...string) string {
+			return TimeFormat(t, optional(mask, ""))
+		},
+		"truncate": func(s string, n int, replace ...string) string {
+			return Truncate(s, optional(replace, ""), n)
+		},
+		"wordTruncate": func(s string, n int, replace ...string) string {
+			return WordTruncate(s, optional(replace, ""), n)
+		},
+		"wrap": func(s string, n int, strip ...bool) string {
+			return Wrap(s, n, optionalBool(strip, false))
+		},
+	}
+}
+
+// linkMode returns the AutoLink mode for the CFWheels link argument,
+// which is either "all", "urls" or "email_addresses".
+func linkMode(link ...string) Link {
+	switch strings.ToLower(optional(link, "all")) {
+	case "urls":
+		return LinkURLs
+	case "email_addresses", "emails":
+		return LinkEmails
+	default:
+		return LinkAll
+	}
+}
+
+// escape returns the HTML escaped string of v, unless v is already template.HTML.
+func escape(v interface{}) string {
+	if h, ok := v.(htmltemplate.HTML); ok {
+		return string(h)
+	}
+
+	return html.EscapeString(fmt.Sprint(v))
+}
+
+// markup returns the string of v without escaping it.
+func markup(v interface{}) string {
+	if h, ok := v.(htmltemplate.HTML); ok {
+		return string(h)
+	}
+
+	return fmt.Sprint(v)
+}
+
+// float returns v as a number, or 0 when v is not a number or a numeric string.
+func float(v interface{}) float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(fmt.Sprint(v)), 64)
+
+	return f
+}
+
+// optional returns the first value of a variadic template argument, or the fallback when there are none.
+func optional(v []string, fallback string) string {
+	if len(v) == 0 {
+		return fallback
+	}
+
+	return v[0]
+}
+
+// optionalBool returns the first value of a variadic template argument, or the fallback when there are none.
+func optionalBool(v []bool, fallback bool) bool {
+	if len(v) == 0 {
+		return fallback
+	}
+
+	return v[0]
+}
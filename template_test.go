@@ -0,0 +1,151 @@
+package cfw_test
+
+import (
+	"bytes"
+	"html/template"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleFuncMap() {
+	const view = `<h1>{{humanize .Title}}</h1>{{.Body | simpleFormat | autoLink}}` +
+		`<a href="/post/{{obfuscateParam .ID}}">{{truncate .Body 18}}</a>`
+
+	tmpl := template.Must(template.New("post").Funcs(cfw.FuncMap()).Parse(view))
+	data := struct {
+		Title string
+		Body  string
+		ID    int
+	}{"helloWorld", "Visit <https://go.dev>\nfor more.", 1}
+
+	if err := tmpl.Execute(os.Stdout, data); err != nil {
+		log.Fatal(err)
+	}
+	// Output: <h1>Hello World</h1><p>Visit &lt;<a href="https://go.dev">https://go.dev</a>&gt;<br />
+	// for more.</p><a href="/post/9b1c6">Visit &lt;https://...</a>
+}
+
+func TestFuncMap(t *testing.T) {
+	t.Parallel()
+
+	created := time.Now().Add(-3 * time.Hour)
+	data := map[string]interface{}{
+		"Body":    "The quick brown <b>fox</b> jumps over the lazy dog",
+		"Email":   "Email hello@cfwheels.com or visit https://cfwheels.org",
+		"Link":    `<p>Go to <a href="https://go.dev" onclick="x()">Go</a></p><script>alert(1)</script>`,
+		"Created": created,
+		"ID":      "15765",
+		"Key":     "b226582",
+	}
+
+	tests := []struct {
+		name string
+		view string
+		want string
+	}{
+		{"truncate", `{{truncate .Body 15}}`, "The quick br..."},
+		{"truncate string", `{{truncate .Body 15 "[more]"}}`, "The quick[more]"},
+		{"word truncate", `{{wordTruncate .Body 3}}`, "The quick brown..."},
+		{"excerpt", `{{excerpt .Body "fox" 5}}`, "...n &lt;b&gt;fox&lt;/b&gt; ..."},
+		{"humanize", `{{humanize "wheelsIsAFramework"}}`, "Wheels Is A Framework"},
+		{"humanize except", `{{humanize "aCfmlFramework" "CFML"}}`, "A CFML Framework"},
+		{"hyphenize", `{{hyphenize "wheelsIsAFramework"}}`, "wheels-is-a-framework"},
+		{"obfuscate", `{{obfuscateParam .ID}}`, "b226582"},
+		{"obfuscate int", `{{obfuscateParam 15765}}`, "b226582"},
+		{"deobfuscate", `{{deobfuscateParam .Key}}`, "15765"},
+		{"time ago", `{{timeAgoInWords .Created}}`, "about 3 hours"},
+		{"distance", `{{distanceOfTimeInWords .Created .Created true}}`, "less than 5 seconds"},
+		{"strip tags", `{{stripTags .Body}}`, "The quick brown fox jumps over the lazy dog"},
+		{"strip links", `{{stripLinks .Link}}`, "<p>Go to Go</p>"},
+		{"sanitize", `{{sanitize .Link}}`, `<p>Go to <a href="https://go.dev" rel="nofollow">Go</a></p>`},
+		{"simple format", `{{simpleFormat "a\n<b>"}}`, "<p>a<br />\n&lt;b&gt;</p>"},
+		{"simple format nowrap", `{{simpleFormat "a\n\nb" false}}`, "a</p>\n\n<p>b"},
+		{
+			"auto link", `{{autoLink .Email}}`,
+			`Email <a href="mailto:hello@cfwheels.com">hello@cfwheels.com</a> or visit ` +
+				`<a href="https://cfwheels.org">https://cfwheels.org</a>`,
+		},
+		{
+			"auto link urls", `{{autoLink .Email "urls"}}`,
+			`Email hello@cfwheels.com or visit <a href="https://cfwheels.org">https://cfwheels.org</a>`,
+		},
+		{
+			"auto link emails", `{{autoLink .Email "email_addresses"}}`,
+			`Email <a href="mailto:hello@cfwheels.com">hello@cfwheels.com</a> or visit https://cfwheels.org`,
+		},
+		{"pipeline", `{{"x\nhttps://go.dev" | simpleFormat | autoLink}}`, "<p>x<br />\n<a href=\"https://go.dev\">https://go.dev</a></p>"},
+		{"attribute", `<a title="{{truncate .Body 12}}">`, `<a title="The quick...">`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tmpl, err := template.New(tt.name).Funcs(cfw.FuncMap()).Parse(tt.view)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var b bytes.Buffer
+			if err := tmpl.Execute(&b, data); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextFuncMap(t *testing.T) {
+	t.Parallel()
+
+	const view = `{{humanize .Name}}: {{truncate .Body 12}}` + "\n" +
+		`{{simpleFormat .Body}}` + "\n" + `{{autoLink .URL}}` + "\n" + `{{stripLinks .URL | autoLink}}`
+
+	data := map[string]string{
+		"Name": "helloWorld",
+		"Body": "<b>bold</b> & more",
+		"URL":  `<a href="/">go.dev</a> https://go.dev`,
+	}
+	want := strings.Join([]string{
+		"Hello World: <b>bold</...",
+		"<p><b>bold</b> & more</p>",
+		`<a href="/">go.dev</a> <a href="https://go.dev">https://go.dev</a>`,
+		`go.dev <a href="https://go.dev">https://go.dev</a>`,
+	}, "\n")
+
+	tmpl, err := texttemplate.New("text").Funcs(cfw.TextFuncMap()).Parse(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := b.String(); got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+}
+
+func TestFuncMapNames(t *testing.T) {
+	t.Parallel()
+
+	html, text := cfw.FuncMap(), cfw.TextFuncMap()
+	if len(html) != len(text) {
+		t.Errorf("FuncMap() has %d helpers, TextFuncMap() has %d", len(html), len(text))
+	}
+
+	for name := range html {
+		if _, ok := text[name]; !ok {
+			t.Errorf("TextFuncMap() is missing the %q helper", name)
+		}
+	}
+
+}
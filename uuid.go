@@ -0,0 +1,157 @@
+package cfw
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	uuidCFML = 35 // uuidCFML is the length of a CFML UUID, 8-4-4-16.
+	uuidRFC  = 36 // uuidRFC is the length of an RFC 4122 UUID, 8-4-4-4-12.
+	uuidHex  = 32 // uuidHex is the length of a UUID without hyphens.
+)
+
+// ErrUUID is returned when a value is not a CFML or RFC 4122 UUID.
+var ErrUUID = errors.New("invalid uuid")
+
+// UUID is a 128-bit universally unique identifier that can be formatted as either a CFML or an RFC 4122 UUID.
+// The CFML format is the uppercase 8-4-4-16 hexadecimal digits returned by the CFML CreateUUID function,
+// such as 7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F,
+// while the RFC 4122 format is the lowercase 8-4-4-4-12 digits, such as 7d8b6c4a-1f2e-4b3c-8d9e-0a1b2c3d4e5f.
+//
+// A UUID implements sql.Scanner and driver.Valuer to read and write the CFML format in a database column.
+type UUID [16]byte
+
+// CreateUUID returns a random CFML UUID.
+//
+// This function is a port of the CFML CreateUUID function,
+// except the UUID is version 4, so it remains valid when converted to the RFC 4122 format.
+func CreateUUID() string {
+	return NewUUID().String()
+}
+
+// NewUUID returns a random, version 4 UUID.
+// It panics if the random number generator fails.
+func NewUUID() UUID {
+	u := UUID{}
+	if _, err := rand.Read(u[:]); err != nil {
+		panic(fmt.Errorf("new uuid: %w", err))
+	}
+
+	const version, variant = 0x40, 0x80
+
+	u[6] = u[6]&0x0f | version
+	u[8] = u[8]&0x3f | variant
+
+	return u
+}
+
+// IsUUID reports whether s is a CFML UUID with the 8-4-4-16 format, in either upper or lowercase.
+// An RFC 4122 UUID is not a CFML UUID.
+func IsUUID(s string) bool {
+	if len(s) != uuidCFML {
+		return false
+	}
+
+	_, err := ParseUUID(s)
+
+	return err == nil
+}
+
+// ParseUUID returns the UUID of s, which can be a CFML UUID, an RFC 4122 UUID,
+// or 32 hexadecimal digits without hyphens, in either upper or lowercase.
+func ParseUUID(s string) (UUID, error) {
+	var hyphens []int
+
+	switch len(s) {
+	case uuidCFML:
+		hyphens = []int{8, 13, 18}
+	case uuidRFC:
+		hyphens = []int{8, 13, 18, 23}
+	case uuidHex:
+	default:
+		return UUID{}, fmt.Errorf("parse uuid %q: %w", s, ErrUUID)
+	}
+
+	digits := []byte(s)
+
+	for i := len(hyphens) - 1; i >= 0; i-- {
+		h := hyphens[i]
+		if digits[h] != '-' {
+			return UUID{}, fmt.Errorf("parse uuid %q: %w", s, ErrUUID)
+		}
+
+		digits = append(digits[:h], digits[h+1:]...)
+	}
+
+	u := UUID{}
+	if _, err := hex.Decode(u[:], digits); err != nil {
+		return UUID{}, fmt.Errorf("parse uuid %q: %w", s, ErrUUID)
+	}
+
+	return u, nil
+}
+
+// String returns the UUID in the uppercase CFML format, 8-4-4-16.
+func (u UUID) String() string {
+	s := strings.ToUpper(hex.EncodeToString(u[:]))
+
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:]
+}
+
+// RFC returns the UUID in the lowercase RFC 4122 format, 8-4-4-4-12.
+func (u UUID) RFC() string {
+	s := hex.EncodeToString(u[:])
+
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// MarshalText returns the UUID in the CFML format.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText sets the UUID from a CFML or RFC 4122 UUID.
+func (u *UUID) UnmarshalText(b []byte) error {
+	v, err := ParseUUID(string(b))
+	if err != nil {
+		return err
+	}
+
+	*u = v
+
+	return nil
+}
+
+// Scan implements sql.Scanner to read a UUID from a database column,
+// which can be a CFML or RFC 4122 UUID string, or 16 bytes of binary.
+// A NULL column sets the zero UUID.
+func (u *UUID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*u = UUID{}
+
+		return nil
+	case string:
+		return u.UnmarshalText([]byte(v))
+	case []byte:
+		if len(v) == len(u) {
+			copy(u[:], v)
+
+			return nil
+		}
+
+		return u.UnmarshalText(v)
+	default:
+		return fmt.Errorf("scan uuid %T: %w", src, ErrUUID)
+	}
+}
+
+// Value implements driver.Valuer to write the UUID to a database column in the CFML format.
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
@@ -0,0 +1,169 @@
+package cfw_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/cfw"
+)
+
+var (
+	_ sql.Scanner   = (*cfw.UUID)(nil)
+	_ driver.Valuer = cfw.UUID{}
+)
+
+func ExampleParseUUID() {
+	u, _ := cfw.ParseUUID("7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F")
+	fmt.Println(u.RFC())
+	u, _ = cfw.ParseUUID("7d8b6c4a-1f2e-4b3c-8d9e-0a1b2c3d4e5f")
+	fmt.Println(u)
+	// Output: 7d8b6c4a-1f2e-4b3c-8d9e-0a1b2c3d4e5f
+	// 7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F
+}
+
+func TestCreateUUID(t *testing.T) {
+	t.Parallel()
+
+	s := cfw.CreateUUID()
+	if !cfw.IsUUID(s) {
+		t.Errorf("CreateUUID() = %q, is not a CFML UUID", s)
+	}
+
+	if s == cfw.CreateUUID() {
+		t.Errorf("CreateUUID() = %q, is not unique", s)
+	}
+
+	u, err := cfw.ParseUUID(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rfc := u.RFC(); rfc[14] != '4' || rfc[19] < '8' || rfc[19] > 'b' {
+		t.Errorf("RFC() = %q, is not a version 4 UUID", rfc)
+	}
+}
+
+func TestIsUUID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    string
+		want bool
+	}{
+		{"cfml", "7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F", true},
+		{"lowercase", "7d8b6c4a-1f2e-4b3c-8d9e0a1b2c3d4e5f", true},
+		{"rfc", "7d8b6c4a-1f2e-4b3c-8d9e-0a1b2c3d4e5f", false},
+		{"hex", "7d8b6c4a1f2e4b3c8d9e0a1b2c3d4e5f", false},
+		{"empty", "", false},
+		{"hyphens", "7D8B6C4A1-F2E-4B3C-8D9E0A1B2C3D4E5F", false},
+		{"digits", "7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5G", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfw.IsUUID(tt.s); got != tt.want {
+				t.Errorf("IsUUID(%q) = %t, want %t", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	t.Parallel()
+
+	const want = "7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F"
+
+	tests := []struct {
+		name    string
+		s       string
+		wantErr error
+	}{
+		{"cfml", want, nil},
+		{"rfc", "7d8b6c4a-1f2e-4b3c-8d9e-0a1b2c3d4e5f", nil},
+		{"hex", "7D8B6C4A1F2E4B3C8D9E0A1B2C3D4E5F", nil},
+		{"empty", "", cfw.ErrUUID},
+		{"rfc hyphens", "7d8b6c4a-1f2e-4b3c-8d9e0-a1b2c3d4e5f", cfw.ErrUUID},
+		{"braces", "{7d8b6c4a-1f2e-4b3c-8d9e-0a1b2c3d4e5}", cfw.ErrUUID},
+		{"digits", "7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5-", cfw.ErrUUID},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			u, err := cfw.ParseUUID(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseUUID(%q) error = %v, want %v", tt.s, err, tt.wantErr)
+			}
+			if err == nil && u.String() != want {
+				t.Errorf("ParseUUID(%q) = %q, want %q", tt.s, u, want)
+			}
+		})
+	}
+}
+
+func TestUUID_Scan(t *testing.T) {
+	t.Parallel()
+
+	const want = "7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F"
+
+	w, _ := cfw.ParseUUID(want)
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    cfw.UUID
+		wantErr error
+	}{
+		{"string", want, w, nil},
+		{"bytes", []byte("7d8b6c4a-1f2e-4b3c-8d9e-0a1b2c3d4e5f"), w, nil},
+		{"binary", w[:], w, nil},
+		{"null", nil, cfw.UUID{}, nil},
+		{"integer", int64(1), cfw.UUID{}, cfw.ErrUUID},
+		{"invalid", "x", cfw.UUID{}, cfw.ErrUUID},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			u := cfw.UUID{}
+			if err := u.Scan(tt.src); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Scan() error = %v, want %v", err, tt.wantErr)
+			}
+			if u != tt.want {
+				t.Errorf("Scan() = %v, want %v", u, tt.want)
+			}
+		})
+	}
+
+	u := w
+	if err := u.Scan(nil); err != nil || u != (cfw.UUID{}) {
+		t.Errorf("Scan(nil) of a reused UUID = %v, %v, want the zero UUID", u, err)
+	}
+}
+
+func TestUUID_Value(t *testing.T) {
+	t.Parallel()
+
+	u, _ := cfw.ParseUUID("7d8b6c4a-1f2e-4b3c-8d9e-0a1b2c3d4e5f")
+
+	v, err := u.Value()
+	if err != nil || v != "7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F" {
+		t.Errorf("Value() = %v, %v", v, err)
+	}
+
+	b, err := json.Marshal(map[string]cfw.UUID{"id": u})
+	if err != nil || string(b) != `{"id":"7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F"}` {
+		t.Errorf("json.Marshal() = %s, %v", b, err)
+	}
+
+	var got map[string]cfw.UUID
+	if err := json.Unmarshal(b, &got); err != nil || got["id"] != u {
+		t.Errorf("json.Unmarshal() = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,85 @@
+package cfw_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleHelpers() {
+	const s = "CFWheels: testing the excerpt view helper to see if it works or not."
+	v1 := cfw.Helpers{}
+	v2 := cfw.Helpers{Version: cfw.V2}
+	fmt.Printf("%q\n", v1.Excerpt(s, "[more]", "EXCERPT", 5))
+	fmt.Printf("%q\n", v2.Excerpt(s, "[more]", "EXCERPT", 5))
+	fmt.Println(v1.Humanize("aCfmlFrameworkWithAnUrl", "CFML URL"))
+	fmt.Println(v2.Humanize("aCfmlFrameworkWithAnUrl", "CFML URL"))
+	// Output: ""
+	// "[more] the excerpt view[more]"
+	// A Cfml Framework With An Url
+	// A CFML Framework With An URL
+}
+
+func TestHelpers_Excerpt(t *testing.T) {
+	t.Parallel()
+
+	h := cfw.Helpers{Version: cfw.V2}
+
+	type args struct {
+		s       string
+		replace string
+		phrase  string
+		n       int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"empty", args{"", "", "", 0}, ""},
+		{"missing", args{"abc def ghi", "", "xyz", 4}, ""},
+		{"default replace", args{"abc def ghi", "", "def", 1}, "... def ..."},
+		{"radius at end", args{"abc def ghi", "...", "def", 4}, "abc def ghi"},
+		{"fold", args{"Straße ÉTÉ fin", "...", "été", 2}, "...e ÉTÉ f..."},
+		{"emoji", args{"The quick brown 🦊 jumps over the lazy 🐕", "💬", "🦊", 3}, "💬wn 🦊 ju💬"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := h.Excerpt(tt.args.s, tt.args.replace, tt.args.phrase, tt.args.n); got != tt.want {
+				t.Errorf("Helpers.Excerpt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHelpers_Humanize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version cfw.Version
+		s       string
+		except  []string
+		want    string
+	}{
+		{"v1 punctuation", cfw.V1, "save/Restore", nil, "Save/ Restore"},
+		{"v2 punctuation", cfw.V2, "save/Restore", nil, "Save/Restore"},
+		{"v1 except list", cfw.V1, "aCfmlFrameworkWithAnUrl", []string{"CFML URL"}, "A Cfml Framework With An Url"},
+		{"v2 except list", cfw.V2, "aCfmlFrameworkWithAnUrl", []string{"CFML URL"}, "A CFML Framework With An URL"},
+		{"v2 excepts", cfw.V2, "aCfmlFrameworkWithAnUrl", []string{"CFML", "URL"}, "A CFML Framework With An URL"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := cfw.Helpers{Version: tt.version}
+			if got := h.Humanize(tt.s, tt.except...); got != tt.want {
+				t.Errorf("Helpers.Humanize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
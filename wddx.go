@@ -0,0 +1,715 @@
+package cfw
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// wddxDate is the layout of a dateTime written by SerializeWDDX.
+const wddxDate = "2006-01-02T15:04:05-07:00"
+
+var (
+	// ErrWDDX is returned when a WDDX packet is invalid or a value cannot be serialized as WDDX.
+	ErrWDDX = errors.New("invalid wddx")
+
+	// rxWDDXDate matches a WDDX dateTime, where the numbers are not always padded with zeros,
+	// such as 2002-6-26T16:47:55-7:0.
+	rxWDDXDate = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})T(\d{1,2}):(\d{1,2}):(\d{1,2})(\.\d+)?` +
+		`(Z|([+-])(\d{1,2})(?::?(\d{1,2}))?)?$`)
+)
+
+// SerializeWDDX returns v as a WDDX 1.0 packet, the XML format of the CFML cfwddx tag.
+//
+// A nil is a null, a bool is a boolean, the integers and floats are a number, a string is a string,
+// a time.Time is a dateTime, a byte slice is binary, other slices and arrays are an array,
+// a map with string keys or a struct is a struct, and a Query is a recordSet.
+// A value with a MarshalText method, such as a UUID, is a string of the text.
+// The struct fields use the same names as SerializeJSON, but the case of the names and the map keys are kept,
+// and the fields of an embedded struct are promoted.
+// An error is returned if v contains a value that cannot be serialized, such as a channel, a function or NaN.
+func SerializeWDDX(v interface{}) (string, error) {
+	var b bytes.Buffer
+
+	b.WriteString("<wddxPacket version='1.0'><header/><data>")
+
+	if err := wddxEncode(&b, reflect.ValueOf(v)); err != nil {
+		return "", err
+	}
+
+	b.WriteString("</data></wddxPacket>")
+
+	return b.String(), nil
+}
+
+// DeserializeWDDX parses a WDDX packet created by SerializeWDDX or CFML and stores its data in v.
+//
+// When v is a pointer to an empty interface, a struct is a map[string]interface{}, an array is a []interface{},
+// a number is a float64, a dateTime is a time.Time, binary is a []byte, and a recordSet is a Query.
+// A dateTime without a time zone is in UTC.
+// Otherwise, the data is stored in v using encoding/json, where the struct keys match the struct fields
+// ignoring case, and a recordSet is an array of structs that each have a key for every column.
+func DeserializeWDDX(s string, v interface{}) error {
+	data, err := wddxPacket(xml.NewDecoder(strings.NewReader(s)))
+	if err != nil {
+		return fmt.Errorf("deserialize wddx: %w", err)
+	}
+
+	if p, ok := v.(*interface{}); ok {
+		*p = data
+
+		return nil
+	}
+
+	b, err := json.Marshal(recordsets(data))
+	if err != nil {
+		return fmt.Errorf("deserialize wddx: %w", err)
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("deserialize wddx: %w", err)
+	}
+
+	return nil
+}
+
+func wddxEncode(b *bytes.Buffer, v reflect.Value) error {
+	if !v.IsValid() {
+		b.WriteString("<null/>")
+
+		return nil
+	}
+
+	if !v.CanInterface() {
+		return wddxKind(b, v)
+	}
+
+	switch x := v.Interface().(type) {
+	case time.Time:
+		b.WriteString("<dateTime>" + x.Format(wddxDate) + "</dateTime>")
+
+		return nil
+	case Query:
+		return wddxRecordset(b, x)
+	case *Query:
+		if x == nil {
+			b.WriteString("<null/>")
+
+			return nil
+		}
+
+		return wddxRecordset(b, *x)
+	case encoding.TextMarshaler:
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			break
+		}
+
+		text, err := x.MarshalText()
+		if err != nil {
+			return fmt.Errorf("serialize wddx %s: %w", v.Type(), err)
+		}
+
+		wddxString(b, string(text))
+
+		return nil
+	}
+
+	return wddxKind(b, v)
+}
+
+// wddxKind writes v using the WDDX of its kind.
+func wddxKind(b *bytes.Buffer, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("<null/>")
+
+			return nil
+		}
+
+		return wddxEncode(b, v.Elem())
+	case reflect.Bool:
+		b.WriteString("<boolean value='" + strconv.FormatBool(v.Bool()) + "'/>")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return wddxNumber(b, float64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return wddxNumber(b, float64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return wddxNumber(b, v.Float())
+	case reflect.String:
+		wddxString(b, v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			b.WriteString("<null/>")
+
+			return nil
+		}
+
+		if v.Type().Elem().Kind() == reflect.Uint8 && v.Kind() == reflect.Slice {
+			b.WriteString("<binary length='" + strconv.Itoa(v.Len()) + "'>")
+			b.WriteString(base64.StdEncoding.EncodeToString(v.Bytes()))
+			b.WriteString("</binary>")
+
+			return nil
+		}
+
+		return wddxArray(b, v)
+	case reflect.Map:
+		return wddxMap(b, v)
+	case reflect.Struct:
+		b.WriteString("<struct>")
+
+		for _, f := range fields(v) {
+			if err := wddxVar(b, f.name, f.value); err != nil {
+				return err
+			}
+		}
+
+		b.WriteString("</struct>")
+	default:
+		return fmt.Errorf("serialize wddx %s: %w", v.Type(), ErrWDDX)
+	}
+
+	return nil
+}
+
+func wddxNumber(b *bytes.Buffer, f float64) error {
+	b.WriteString("<number>")
+
+	if err := writeNumber(b, f); err != nil {
+		return fmt.Errorf("serialize wddx %v: %w", f, ErrWDDX)
+	}
+
+	b.WriteString("</number>")
+
+	return nil
+}
+
+func wddxArray(b *bytes.Buffer, v reflect.Value) error {
+	b.WriteString("<array length='" + strconv.Itoa(v.Len()) + "'>")
+
+	for i := 0; i < v.Len(); i++ {
+		if err := wddxEncode(b, v.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	b.WriteString("</array>")
+
+	return nil
+}
+
+func wddxMap(b *bytes.Buffer, v reflect.Value) error {
+	if v.IsNil() {
+		b.WriteString("<null/>")
+
+		return nil
+	}
+
+	if v.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("serialize wddx %s: %w", v.Type(), ErrWDDX)
+	}
+
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	b.WriteString("<struct>")
+
+	for _, k := range keys {
+		if err := wddxVar(b, k.String(), v.MapIndex(k)); err != nil {
+			return err
+		}
+	}
+
+	b.WriteString("</struct>")
+
+	return nil
+}
+
+func wddxVar(b *bytes.Buffer, name string, v reflect.Value) error {
+	b.WriteString("<var name='" + wddxAttr(name) + "'>")
+
+	if err := wddxEncode(b, v); err != nil {
+		return err
+	}
+
+	b.WriteString("</var>")
+
+	return nil
+}
+
+func wddxRecordset(b *bytes.Buffer, q Query) error {
+	names := make([]string, len(q.Columns))
+	for i, c := range q.Columns {
+		names[i] = wddxAttr(c)
+	}
+
+	b.WriteString("<recordset rowCount='" + strconv.Itoa(len(q.Data)) + "' fieldNames='" + strings.Join(names, ",") + "'>")
+
+	for i, name := range names {
+		b.WriteString("<field name='" + name + "'>")
+
+		for _, row := range q.Data {
+			var cell interface{}
+			if i < len(row) {
+				cell = row[i]
+			}
+
+			if err := wddxEncode(b, reflect.ValueOf(cell)); err != nil {
+				return err
+			}
+		}
+
+		b.WriteString("</field>")
+	}
+
+	b.WriteString("</recordset>")
+
+	return nil
+}
+
+// wddxString writes s as a WDDX string, where the control characters other than the tab and LF are char elements,
+// so a CR is not normalized by the XML parser.
+func wddxString(b *bytes.Buffer, s string) {
+	const hexDigits = "0123456789abcdef"
+
+	b.WriteString("<string>")
+
+	for _, r := range s {
+		switch {
+		case r == '&':
+			b.WriteString("&amp;")
+		case r == '<':
+			b.WriteString("&lt;")
+		case r == '>':
+			b.WriteString("&gt;")
+		case r < ' ' && r != '\t' && r != '\n':
+			b.WriteString("<char code='")
+			b.WriteByte(hexDigits[r>>4])
+			b.WriteByte(hexDigits[r&0xf])
+			b.WriteString("'/>")
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	b.WriteString("</string>")
+}
+
+// wddxAttr returns s escaped for a single quoted attribute value.
+func wddxAttr(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", "'", "&apos;").Replace(s)
+}
+
+// wddxPacket returns the data of the wddxPacket element read by d.
+func wddxPacket(d *xml.Decoder) (interface{}, error) {
+	start, err := nextStart(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if start.Name.Local != "wddxPacket" {
+		return nil, fmt.Errorf("%w: %s element is not a wddxPacket", ErrWDDX, start.Name.Local)
+	}
+
+	for {
+		start, err := nextStart(d)
+		if err != nil {
+			return nil, err
+		}
+
+		if start.Name.Local != "data" {
+			if err := d.Skip(); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		values, err := wddxValues(d)
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(values) {
+		case 0:
+			return nil, nil
+		case 1:
+			return values[0], nil
+		default:
+			return values, nil
+		}
+	}
+}
+
+// nextStart returns the next start element read by d, where an end element or the end of the input is an error.
+func nextStart(d *xml.Decoder) (xml.StartElement, error) {
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return xml.StartElement{}, fmt.Errorf("%w: unexpected end of packet", ErrWDDX)
+		}
+
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			return t, nil
+		case xml.EndElement:
+			return xml.StartElement{}, fmt.Errorf("%w: unexpected end of %s", ErrWDDX, t.Name.Local)
+		}
+	}
+}
+
+// wddxValues returns the values of the child elements, up to the end of the current element.
+func wddxValues(d *xml.Decoder) ([]interface{}, error) {
+	values := []interface{}{}
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			v, err := wddxValue(d, t)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, v)
+		case xml.EndElement:
+			return values, nil
+		}
+	}
+}
+
+// wddxValue returns the value of the start element, reading up to its end element.
+func wddxValue(d *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch strings.ToLower(start.Name.Local) {
+	case "null":
+		return nil, d.Skip()
+	case "boolean":
+		if err := d.Skip(); err != nil {
+			return nil, err
+		}
+
+		v, err := strconv.ParseBool(attr(start, "value"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: boolean %q", ErrWDDX, attr(start, "value"))
+		}
+
+		return v, nil
+	case "string":
+		return wddxText(d, true)
+	case "number":
+		s, err := wddxText(d, false)
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: number %q", ErrWDDX, s)
+		}
+
+		return f, nil
+	case "datetime":
+		s, err := wddxText(d, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return parseWDDXDate(strings.TrimSpace(s))
+	case "binary":
+		s, err := wddxText(d, false)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+		if err != nil {
+			return nil, fmt.Errorf("%w: binary: %s", ErrWDDX, err)
+		}
+
+		return b, nil
+	case "array":
+		return wddxValues(d)
+	case "struct":
+		return wddxStruct(d)
+	case "recordset":
+		return wddxQuery(d, start)
+	default:
+		return nil, fmt.Errorf("%w: unknown %s element", ErrWDDX, start.Name.Local)
+	}
+}
+
+// wddxText returns the text up to the end of the current element.
+// When chars is true, a char element is replaced with the character of its hexadecimal code.
+func wddxText(d *xml.Decoder, chars bool) (string, error) {
+	var sb strings.Builder
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+
+		switch t := tok.(type) {
+		case xml.CharData:
+			sb.Write(t)
+		case xml.StartElement:
+			if !chars || t.Name.Local != "char" {
+				return "", fmt.Errorf("%w: unexpected %s element", ErrWDDX, t.Name.Local)
+			}
+
+			code, err := strconv.ParseUint(attr(t, "code"), hexadecimal, 32)
+			if err != nil {
+				return "", fmt.Errorf("%w: char code %q", ErrWDDX, attr(t, "code"))
+			}
+
+			sb.WriteRune(rune(code))
+
+			if err := d.Skip(); err != nil {
+				return "", err
+			}
+		case xml.EndElement:
+			return sb.String(), nil
+		}
+	}
+}
+
+func wddxStruct(d *xml.Decoder) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "var" {
+				return nil, fmt.Errorf("%w: unexpected %s element in a struct", ErrWDDX, t.Name.Local)
+			}
+
+			v, err := wddxOne(d)
+			if err != nil {
+				return nil, err
+			}
+
+			m[attr(t, "name")] = v
+		case xml.EndElement:
+			return m, nil
+		}
+	}
+}
+
+func wddxQuery(d *xml.Decoder, start xml.StartElement) (Query, error) {
+	q := Query{Columns: []string{}}
+
+	rows, err := strconv.Atoi(attr(start, "rowCount"))
+	if err != nil || rows < 0 {
+		return Query{}, fmt.Errorf("%w: recordset rowCount %q", ErrWDDX, attr(start, "rowCount"))
+	}
+
+	if names := attr(start, "fieldNames"); names != "" {
+		q.Columns = strings.Split(names, ",")
+	}
+	// the rows are only made once the values of every field are read,
+	// so the rowCount attribute is checked against the values instead of being trusted
+	cols := make([][]interface{}, len(q.Columns))
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return Query{}, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "field" {
+				return Query{}, fmt.Errorf("%w: unexpected %s element in a recordset", ErrWDDX, t.Name.Local)
+			}
+
+			if err := wddxField(d, t, q.Columns, cols); err != nil {
+				return Query{}, err
+			}
+		case xml.EndElement:
+			return wddxRows(q, cols, rows)
+		}
+	}
+}
+
+// wddxField reads the values of a recordset field into its column of cols, where names are the column names.
+func wddxField(d *xml.Decoder, start xml.StartElement, names []string, cols [][]interface{}) error {
+	name := attr(start, "name")
+	col := -1
+
+	for i, c := range names {
+		if strings.EqualFold(c, name) {
+			col = i
+
+			break
+		}
+	}
+
+	values, err := wddxValues(d)
+	if err != nil {
+		return err
+	}
+
+	if col < 0 {
+		return fmt.Errorf("%w: recordset field %q", ErrWDDX, name)
+	}
+
+	cols[col] = values
+
+	return nil
+}
+
+// wddxRows returns q with the rows of the column values in cols,
+// where every column must have the number of values given by the rowCount attribute.
+func wddxRows(q Query, cols [][]interface{}, rows int) (Query, error) {
+	if len(cols) == 0 && rows > 0 {
+		return Query{}, fmt.Errorf("%w: recordset rowCount %d without fields", ErrWDDX, rows)
+	}
+
+	for i, values := range cols {
+		if len(values) != rows {
+			return Query{}, fmt.Errorf("%w: recordset field %q has %d of %d rows", ErrWDDX, q.Columns[i], len(values), rows)
+		}
+	}
+
+	q.Data = make([][]interface{}, rows)
+	for r := range q.Data {
+		q.Data[r] = make([]interface{}, len(cols))
+		for i := range cols {
+			q.Data[r][i] = cols[i][r]
+		}
+	}
+
+	return q, nil
+}
+
+// wddxOne returns the single value up to the end of the current element.
+func wddxOne(d *xml.Decoder) (interface{}, error) {
+	values, err := wddxValues(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) != 1 {
+		return nil, fmt.Errorf("%w: %d values in a var", ErrWDDX, len(values))
+	}
+
+	return values[0], nil
+}
+
+// attr returns the value of the named attribute of the element, ignoring case.
+func attr(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if strings.EqualFold(a.Name.Local, name) {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// parseWDDXDate returns the time of a WDDX dateTime, where a dateTime without a time zone is in UTC.
+func parseWDDXDate(s string) (time.Time, error) {
+	const (
+		year = iota + 1
+		month
+		day
+		hour
+		minute
+		second
+		fraction
+		zone
+		sign
+		zoneHour
+		zoneMinute
+	)
+
+	m := rxWDDXDate.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, fmt.Errorf("%w: dateTime %q", ErrWDDX, s)
+	}
+
+	n := func(i int) int {
+		v, _ := strconv.Atoi(m[i])
+
+		return v
+	}
+
+	nsec := 0
+	if m[fraction] != "" {
+		f, _ := strconv.ParseFloat(m[fraction], 64)
+		nsec = int(f * float64(time.Second))
+	}
+
+	loc := time.UTC
+
+	if m[sign] != "" {
+		const secondsPerHour, secondsPerMinute = 3600, 60
+
+		offset := n(zoneHour)*secondsPerHour + n(zoneMinute)*secondsPerMinute
+		if m[sign] == "-" {
+			offset = -offset
+		}
+
+		loc = time.FixedZone("", offset)
+	}
+
+	return time.Date(n(year), time.Month(n(month)), n(day), n(hour), n(minute), n(second), nsec, loc), nil
+}
+
+// recordsets returns the deserialized WDDX with each Query replaced by an array of maps with a key for every column.
+func recordsets(v interface{}) interface{} {
+	switch x := v.(type) {
+	case []interface{}:
+		for i := range x {
+			x[i] = recordsets(x[i])
+		}
+
+		return x
+	case map[string]interface{}:
+		for k := range x {
+			x[k] = recordsets(x[k])
+		}
+
+		return x
+	case Query:
+		rows := make([]interface{}, 0, len(x.Data))
+
+		for _, row := range x.Data {
+			m := make(map[string]interface{}, len(x.Columns))
+			for i, c := range x.Columns {
+				m[c] = recordsets(row[i])
+			}
+
+			rows = append(rows, m)
+		}
+
+		return rows
+	default:
+		return v
+	}
+}
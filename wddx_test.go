@@ -0,0 +1,228 @@
+package cfw_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bengarrett/cfw"
+)
+
+func ExampleSerializeWDDX() {
+	s, _ := cfw.SerializeWDDX(map[string]interface{}{"id": 1, "tags": []string{"a"}})
+	fmt.Println(s)
+	// Output: <wddxPacket version='1.0'><header/><data><struct><var name='id'><number>1.0</number></var><var name='tags'><array length='1'><string>a</string></array></var></struct></data></wddxPacket>
+}
+
+func ExampleDeserializeWDDX() {
+	const packet = `<wddxPacket version='1.0'><header/><data><struct type='coldfusion.runtime.Struct'>` +
+		`<var name='USERID'><number>42.0</number></var><var name='NAME'><string>Ada</string></var>` +
+		`</struct></data></wddxPacket>`
+
+	var session struct {
+		UserID int
+		Name   string
+	}
+	_ = cfw.DeserializeWDDX(packet, &session)
+	fmt.Printf("%+v\n", session)
+	// Output: {UserID:42 Name:Ada}
+}
+
+func TestSerializeWDDX(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int
+		Name string `json:"name,omitempty"`
+		Skip bool   `json:"-"`
+	}
+
+	type Inner struct {
+		ID   int
+		Role string
+	}
+
+	type account struct {
+		Inner
+		ID   string
+		UUID cfw.UUID
+	}
+
+	type inner struct {
+		Role string
+	}
+
+	type hidden struct {
+		inner
+	}
+
+	uuid, _ := cfw.ParseUUID("7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F")
+
+	const head, tail = "<wddxPacket version='1.0'><header/><data>", "</data></wddxPacket>"
+
+	tests := []struct {
+		name    string
+		v       interface{}
+		want    string
+		wantErr error
+	}{
+		{"nil", nil, "<null/>", nil},
+		{"bool", false, "<boolean value='false'/>", nil},
+		{"int", 3, "<number>3.0</number>", nil},
+		{"float", 1e-4, "<number>1.0E-4</number>", nil},
+		{"string", "a < b & c > d", "<string>a &lt; b &amp; c &gt; d</string>", nil},
+		{"control", "a\r\n\tb\x0c", "<string>a<char code='0d'/>\n\tb<char code='0c'/></string>", nil},
+		{"quote", `"it's"`, `<string>"it's"</string>`, nil},
+		{
+			"date", time.Date(2002, time.June, 26, 16, 47, 55, 0, time.FixedZone("", -7*3600)),
+			"<dateTime>2002-06-26T16:47:55-07:00</dateTime>", nil,
+		},
+		{"binary", []byte("hi"), "<binary length='2'>aGk=</binary>", nil},
+		{"array", []interface{}{1, nil}, "<array length='2'><number>1.0</number><null/></array>", nil},
+		{"map", map[string]bool{"b'": true}, "<struct><var name='b&apos;'><boolean value='true'/></var></struct>", nil},
+		{"struct", user{ID: 1}, "<struct><var name='ID'><number>1.0</number></var></struct>", nil},
+		{
+			"recordset", &cfw.Query{Columns: []string{"ID", "NAME"}, Data: [][]interface{}{{1, "a"}, {2}}},
+			"<recordset rowCount='2' fieldNames='ID,NAME'><field name='ID'><number>1.0</number><number>2.0</number></field>" +
+				"<field name='NAME'><string>a</string><null/></field></recordset>",
+			nil,
+		},
+		{"uuid", uuid, "<string>7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F</string>", nil},
+		{
+			"embedded", account{Inner{1, "admin"}, "a", uuid},
+			"<struct><var name='Role'><string>admin</string></var><var name='ID'><string>a</string></var>" +
+				"<var name='UUID'><string>7D8B6C4A-1F2E-4B3C-8D9E0A1B2C3D4E5F</string></var></struct>",
+			nil,
+		},
+		{
+			"unexported embedded", hidden{inner{"admin"}},
+			"<struct><var name='Role'><string>admin</string></var></struct>", nil,
+		},
+		{"nan", math.Inf(1), "", cfw.ErrWDDX},
+		{"chan", make(chan int), "", cfw.ErrWDDX},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := cfw.SerializeWDDX(tt.v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SerializeWDDX() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if want := head + tt.want + tail; got != want {
+				t.Errorf("SerializeWDDX() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDeserializeWDDX(t *testing.T) {
+	t.Parallel()
+
+	packet := func(data string) string {
+		return "<wddxPacket version='1.0'><header><comment>legacy</comment></header><data>" + data + "</data></wddxPacket>"
+	}
+
+	tests := []struct {
+		name    string
+		s       string
+		want    interface{}
+		wantErr bool
+	}{
+		{"empty", packet(""), nil, false},
+		{"null", packet("<null/>"), nil, false},
+		{"boolean", packet("<boolean value='true'/>"), true, false},
+		{"number", packet("<number>-1.5E2</number>"), -150.0, false},
+		{"string", packet("<string>a &amp; b<char code='0D'/><char code='0a'/></string>"), "a & b\r\n", false},
+		{"cfml date", packet("<dateTime>2002-6-26T16:47:55-7:0</dateTime>"),
+			time.Date(2002, time.June, 26, 16, 47, 55, 0, time.FixedZone("", -7*3600)), false},
+		{"utc date", packet("<dateTime>2026-01-05T15:04:05.5Z</dateTime>"),
+			time.Date(2026, time.January, 5, 15, 4, 5, 5e8, time.UTC), false},
+		{"binary", packet("<binary length='2'>aG\nk=</binary>"), []byte("hi"), false},
+		{"array", packet("<array length='2'><string>a</string><number>1</number></array>"), []interface{}{"a", 1.0}, false},
+		{
+			"struct", packet("<struct type='coldfusion.runtime.Struct'><var name='A'><struct></struct></var></struct>"),
+			map[string]interface{}{"A": map[string]interface{}{}}, false,
+		},
+		{
+			"recordset", packet("<recordset rowCount='2' fieldNames='ID,NAME' type='coldfusion.sql.QueryTable'>" +
+				"<field name='NAME'><string>a</string><string>b</string></field>" +
+				"<field name='id'><number>1</number><number>2</number></field></recordset>"),
+			cfw.Query{Columns: []string{"ID", "NAME"}, Data: [][]interface{}{{1.0, "a"}, {2.0, "b"}}}, false,
+		},
+		{"empty recordset", packet("<recordset rowCount='0' fieldNames=''></recordset>"),
+			cfw.Query{Columns: []string{}, Data: [][]interface{}{}}, false},
+		{"not a packet", "<data><null/></data>", nil, true},
+		{"truncated", "<wddxPacket version='1.0'><header/><data><string>a", nil, true},
+		{"unknown", packet("<float>1</float>"), nil, true},
+		{"bad number", packet("<number>one</number>"), nil, true},
+		{"bad date", packet("<dateTime>yesterday</dateTime>"), nil, true},
+		{"bad var", packet("<struct><var name='a'></var></struct>"), nil, true},
+		{"bad field", packet("<recordset rowCount='1' fieldNames='A'><field name='A'></field></recordset>"), nil, true},
+		{"huge rowCount", packet("<recordset rowCount='999999999999' fieldNames='A'>" +
+			"<field name='A'><number>1</number></field></recordset>"), nil, true},
+		{"rowCount without fields", packet("<recordset rowCount='999999999999' fieldNames=''></recordset>"), nil, true},
+		{"missing field", packet("<recordset rowCount='1' fieldNames='A,B'>" +
+			"<field name='A'><number>1</number></field></recordset>"), nil, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got interface{}
+			err := cfw.DeserializeWDDX(tt.s, &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeserializeWDDX() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeserializeWDDX() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeserializeWDDXStruct(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		ID   int
+		Name string
+	}
+
+	type cache struct {
+		Updated time.Time
+		Avatar  []byte
+		Rows    []row
+	}
+
+	want := cache{
+		Updated: time.Date(2026, time.January, 5, 15, 4, 5, 0, time.UTC),
+		Avatar:  []byte{0, 1, 2},
+		Rows:    []row{{1, "a"}, {2, "b"}},
+	}
+
+	s, err := cfw.SerializeWDDX(map[string]interface{}{
+		"UPDATED": want.Updated,
+		"AVATAR":  want.Avatar,
+		"ROWS":    cfw.Query{Columns: []string{"ID", "NAME"}, Data: [][]interface{}{{1, "a"}, {2, "b"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got cache
+	if err := cfw.DeserializeWDDX(s, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Updated.Equal(want.Updated) || !reflect.DeepEqual(got.Avatar, want.Avatar) ||
+		!reflect.DeepEqual(got.Rows, want.Rows) {
+		t.Errorf("DeserializeWDDX() = %+v, want %+v", got, want)
+	}
+}